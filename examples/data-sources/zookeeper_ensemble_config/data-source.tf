@@ -0,0 +1,11 @@
+data "zookeeper_ensemble_config" "current" {}
+
+# Connection string of the ensemble, to hand over to its clients
+output "zookeeper_connect_string" {
+  value = data.zookeeper_ensemble_config.current.connect_string
+}
+
+# Hosts of the voting members, ex. to open the quorum and election ports between them
+output "zookeeper_participants_hosts" {
+  value = [for m in data.zookeeper_ensemble_config.current.members : m.host if m.role == "participant"]
+}
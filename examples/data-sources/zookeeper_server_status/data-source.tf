@@ -0,0 +1,12 @@
+data "zookeeper_server_status" "ensemble" {}
+
+output "zookeeper_leader" {
+  value = one([for s in data.zookeeper_server_status.ensemble.status : s.server if s.mode == "leader"])
+}
+
+check "zookeeper_ensemble" {
+  assert {
+    condition     = alltrue([for s in data.zookeeper_server_status.ensemble.status : s.error == ""])
+    error_message = "Some ZooKeeper servers are unreachable"
+  }
+}
@@ -0,0 +1,15 @@
+data "zookeeper_subtree_stats" "app" {
+  path = "/app"
+}
+
+# Make sure nobody is still using the subtree, before deleting it
+check "app_unused" {
+  assert {
+    condition     = length(data.zookeeper_subtree_stats.app.ephemerals) == 0
+    error_message = "Ephemeral ZNodes still under /app: ${join(", ", data.zookeeper_subtree_stats.app.ephemerals[*].path)}"
+  }
+}
+
+output "app_size" {
+  value = "${data.zookeeper_subtree_stats.app.descendant_count} ZNodes, ${data.zookeeper_subtree_stats.app.data_bytes} bytes"
+}
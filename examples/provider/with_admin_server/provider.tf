@@ -0,0 +1,7 @@
+# Four letter words are disabled: use the AdminServer of each server instead
+provider "zookeeper" {
+  servers          = "zk-01:2181,zk-02:2181,zk-03:2181"
+  admin_server_url = "https://{host}:8080/commands"
+  tls_ca_file      = "/etc/zookeeper/ca.pem"
+  health_check     = true
+}
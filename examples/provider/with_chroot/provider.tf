@@ -0,0 +1,11 @@
+# All ZNode paths are relative to `/teams/foo`
+provider "zookeeper" {
+  servers = "zk-01:2181,zk-02:2181"
+  chroot  = "/teams/foo"
+}
+
+# Managed at `/teams/foo/config/feature-flags`
+resource "zookeeper_znode" "feature_flags" {
+  path = "/config/feature-flags"
+  data = "{ \"new-ui\": true }"
+}
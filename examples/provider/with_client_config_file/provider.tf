@@ -0,0 +1,3 @@
+provider "zookeeper" {
+  client_config_file = "/etc/zookeeper/zookeeper-client.properties"
+}
@@ -0,0 +1,8 @@
+# Refuse to apply unless all 3 servers are healthy,
+# and none of them is struggling to keep up
+provider "zookeeper" {
+  servers                               = "zk-01:2181,zk-02:2181,zk-03:2181"
+  health_check                          = true
+  health_check_min_quorum               = 3
+  health_check_max_outstanding_requests = 100
+}
@@ -0,0 +1,9 @@
+provider "zookeeper" {
+  servers                 = "zk-server-01:2182,zk-server-02:2182"
+  session_timeout         = 30
+  tls_enabled             = true
+  tls_keystore_file       = "/path/to/keystore.jks"
+  tls_keystore_password   = var.zk_keystore_password
+  tls_truststore_file     = "/path/to/truststore.jks"
+  tls_truststore_password = var.zk_truststore_password
+}
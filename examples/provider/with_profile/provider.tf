@@ -0,0 +1,6 @@
+# Uses the `prod` profile defined in `~/.zookeeper/profiles.toml`,
+# overriding its `session_timeout`
+provider "zookeeper" {
+  profile         = "prod"
+  session_timeout = 60
+}
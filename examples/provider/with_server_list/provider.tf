@@ -0,0 +1,7 @@
+provider "zookeeper" {
+  server_list = [
+    "zk-01.example.com:2181",
+    "zk-02.example.com:2181",
+    "[2001:db8::3]:2181",
+  ]
+}
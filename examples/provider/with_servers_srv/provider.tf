@@ -0,0 +1,8 @@
+# Servers are discovered from the DNS SRV record, ex.:
+#
+#   _zookeeper._tcp.example.com. 300 IN SRV 0 0 2181 zk-01.example.com.
+#   _zookeeper._tcp.example.com. 300 IN SRV 0 0 2181 zk-02.example.com.
+#   _zookeeper._tcp.example.com. 300 IN SRV 0 0 2181 zk-03.example.com.
+provider "zookeeper" {
+  servers_srv = "_zookeeper._tcp.example.com"
+}
@@ -0,0 +1,7 @@
+# Reconfiguring the ensemble requires the ZooKeeper superuser
+provider "zookeeper" {
+  servers            = "zk-01:2181,zk-02:2181,zk-03:2181"
+  username           = "username"
+  password           = "password"
+  superuser_password = var.zookeeper_superuser_password
+}
@@ -0,0 +1,8 @@
+# Servers are listed local datacenter first, and tried in that order
+provider "zookeeper" {
+  servers                = "zk-dc1-01:2181,zk-dc1-02:2181,zk-dc2-01:2181"
+  server_order           = "ordered"
+  connect_timeout        = 5
+  max_reconnect_attempts = 10
+  max_buffer_size        = 4194304 # 4MB
+}
@@ -0,0 +1,6 @@
+# Every write must reach all the servers within 60 seconds
+provider "zookeeper" {
+  servers              = "zk-01:2181,zk-02:2181,zk-03:2181"
+  wait_for_propagation = true
+  propagation_timeout  = 60
+}
@@ -0,0 +1 @@
+$ terraform import zookeeper_ensemble_membership.ensemble /zookeeper/config
@@ -0,0 +1,24 @@
+# Three participants, and a fourth server joining as observer:
+# once it has caught up, it can be promoted by changing its role to "participant"
+resource "zookeeper_ensemble_membership" "ensemble" {
+  member {
+    id   = 1
+    host = "zk-01"
+  }
+
+  member {
+    id   = 2
+    host = "zk-02"
+  }
+
+  member {
+    id   = 3
+    host = "zk-03"
+  }
+
+  member {
+    id   = 4
+    host = "zk-04"
+    role = "observer"
+  }
+}
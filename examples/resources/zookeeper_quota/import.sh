@@ -0,0 +1 @@
+$ terraform import zookeeper_quota.app /app
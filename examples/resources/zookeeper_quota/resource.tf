@@ -0,0 +1,11 @@
+resource "zookeeper_znode" "app" {
+  path = "/app"
+}
+
+# Warn when the subtree grows beyond 1000 ZNodes,
+# and refuse writes beyond 10 MiB of data (ZooKeeper 3.7 or later)
+resource "zookeeper_quota" "app" {
+  path             = zookeeper_znode.app.path
+  count_limit      = 1000
+  bytes_hard_limit = 10485760
+}
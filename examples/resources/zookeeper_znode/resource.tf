@@ -9,3 +9,36 @@ resource "zookeeper_znode" "napoli_logo" {
   path        = "/forza/napoli/logo"
   data_base64 = filebase64("logo.png")
 }
+
+# On destroy, move the ZNode (and all its descendants) to `/.trash/<timestamp>/forza/campioni`,
+# instead of deleting it
+resource "zookeeper_znode" "campioni" {
+  path          = "/forza/campioni"
+  data          = "1987, 1990, 2023"
+  delete_policy = "trash"
+}
+
+# Show, in the plan, how many descendants (and bytes) destroying the ZNode would delete
+resource "zookeeper_znode" "tifosi" {
+  path                 = "/forza/tifosi"
+  track_destroy_impact = true
+}
+
+# On destroy, delete also the (empty) parents that were created together with the ZNode
+resource "zookeeper_znode" "curva_b" {
+  path                   = "/forza/stadio/curva_b"
+  delete_created_parents = true
+}
+
+# Take ownership of a ZNode, if it was already created by hand, keeping its data
+resource "zookeeper_znode" "maradona" {
+  path           = "/forza/napoli/maradona"
+  adopt_existing = "adopt"
+}
+
+# Changing the path moves the ZNode (and all its descendants), instead of replacing it
+resource "zookeeper_znode" "stadio" {
+  path                = "/forza/stadio/diego_armando_maradona"
+  data                = "Fuorigrotta"
+  move_on_path_change = true
+}
@@ -0,0 +1 @@
+$ terraform import zookeeper_znode_copy.app /staging/app:/prod/app
@@ -0,0 +1,28 @@
+# Promote the configuration from staging to production, once:
+# changes to either side are reported as drift (see `in_sync`), but not reconciled
+resource "zookeeper_znode_copy" "app" {
+  source_path      = "/staging/app"
+  destination_path = "/prod/app"
+  exclude          = ["secrets", "*/credentials"]
+  delete_policy    = "retain"
+}
+
+# Keep a read-only mirror of the feature flags, reconciled on every apply
+resource "zookeeper_znode_copy" "features" {
+  source_path      = "/prod/app/features"
+  destination_path = "/mirror/app/features"
+  mode             = "reconcile"
+  prune            = true
+
+  # Writable only by the user the provider authenticates as (to keep it in sync), readable by anyone
+  acl {
+    scheme      = "digest"
+    id          = "terraform:OpH1Gj2XfdYGpYwFSfK4jCJtvrE="
+    permissions = 31 # all
+  }
+  acl {
+    scheme      = "world"
+    id          = "anyone"
+    permissions = 1 # read
+  }
+}
@@ -0,0 +1,19 @@
+# Pause the deployment until the application reports it's ready,
+# waiting again whenever a new version is deployed
+resource "zookeeper_znode_wait" "app_ready" {
+  path         = "/app/status"
+  data_matches = "^ready"
+  timeout      = 600
+
+  triggers = {
+    version = "1.2.3"
+  }
+}
+
+# Pause until at least 3 workers registered
+resource "zookeeper_znode_wait" "workers" {
+  path         = "/app/workers"
+  min_children = 3
+
+  depends_on = [zookeeper_znode_wait.app_ready]
+}
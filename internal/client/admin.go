@@ -0,0 +1,310 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// AdminServerHostPlaceholder is replaced, in the AdminServer URL, with the host of each server.
+const AdminServerHostPlaceholder = "{host}"
+
+// adminServerTimeout is the timeout to execute an AdminServer command.
+const adminServerTimeout = 10 * time.Second
+
+var (
+	// ErrInvalidAdminServerURL returned when the AdminServer URL is not a valid `http(s)` URL.
+	ErrInvalidAdminServerURL = errors.New("invalid AdminServer URL")
+
+	// ErrAdminServerNotConfigured returned when attempting an AdminServer command,
+	// without having configured the AdminServer URL (see Config.AdminServerURL).
+	ErrAdminServerNotConfigured = errors.New("AdminServer URL not configured")
+
+	// ErrAdminServerUnauthorized returned when the AdminServer refuses a command,
+	// because the Client is not authenticated (or authorized) to execute it.
+	ErrAdminServerUnauthorized = errors.New("AdminServer command not authorized")
+)
+
+// ValidateAdminServerURL validates the URL of the ZooKeeper AdminServer commands (ex. `https://{host}:8080/commands`).
+//
+// AdminServerHostPlaceholder is replaced with the host of each server, so that the commands
+// are sent to the AdminServer of each server.
+func ValidateAdminServerURL(adminServerURL string) error {
+	u, err := url.Parse(strings.ReplaceAll(adminServerURL, AdminServerHostPlaceholder, "localhost"))
+	if err != nil {
+		return fmt.Errorf("%w '%s': %w", ErrInvalidAdminServerURL, adminServerURL, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf(
+			"%w '%s': expected 'http(s)://host[:port]/path'",
+			ErrInvalidAdminServerURL,
+			adminServerURL,
+		)
+	}
+
+	return nil
+}
+
+// adminServer is a client of the ZooKeeper AdminServer, the HTTP API that replaces
+// the four letter words in newer ensembles.
+//
+// See: https://zookeeper.apache.org/doc/current/zookeeperAdmin.html#sc_adminserver.
+type adminServer struct {
+	urlTemplate string
+	httpClient  *http.Client
+	username    string
+	password    string
+}
+
+func newAdminServer(
+	urlTemplate string,
+	tlsConfig *tls.Config,
+	proxyDialer proxy.ContextDialer,
+	username, password string,
+) (*adminServer, error) {
+	if err := ValidateAdminServerURL(urlTemplate); err != nil {
+		return nil, err
+	}
+
+	return &adminServer{
+		urlTemplate: strings.TrimSuffix(urlTemplate, "/"),
+		httpClient: &http.Client{
+			Timeout: adminServerTimeout,
+			Transport: &http.Transport{
+				DialContext:     proxyDialer.DialContext,
+				TLSClientConfig: tlsConfig.Clone(),
+			},
+		},
+		username: username,
+		password: password,
+	}, nil
+}
+
+// commandURL returns the URL of the given command, on the AdminServer of the given server.
+func (a *adminServer) commandURL(server, command string) string {
+	host := server
+	if h, _, err := net.SplitHostPort(server); err == nil {
+		host = h
+	}
+	return strings.ReplaceAll(
+		a.urlTemplate,
+		AdminServerHostPlaceholder,
+		bracketIPv6(host),
+	) + "/" + command
+}
+
+// command executes the given command on the AdminServer of the given server, and returns its response.
+//
+// Numbers in the response are decoded as json.Number.
+func (a *adminServer) command(server, command string) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), adminServerTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.commandURL(server, command), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send '%s' to '%s': %w", command, server, err)
+	}
+	if a.username != "" {
+		// NOTE: The AdminServer expects `<auth scheme> <auth data>`, like the `addauth` CLI command
+		req.Header.Set("Authorization", "digest "+a.username+":"+a.password)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send '%s' to '%s': %w", command, server, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf(
+			"failed to send '%s' to '%s': %w",
+			command,
+			server,
+			ErrAdminServerUnauthorized,
+		)
+	}
+
+	var response map[string]interface{}
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err = decoder.Decode(&response); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, NewAdminServerCommandError(server, command, resp.Status)
+		}
+		return nil, fmt.Errorf("failed to read '%s' response from '%s': %w", command, server, err)
+	}
+
+	if errMsg := jsonString(response["error"]); errMsg != "" {
+		return nil, NewAdminServerCommandError(server, command, errMsg)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewAdminServerCommandError(server, command, resp.Status)
+	}
+
+	return response, nil
+}
+
+// AdminServerCommand executes the given command (ex. `monitor`) on the AdminServer of the given server,
+// and returns its response. Numbers in the response are decoded as json.Number.
+//
+// Fails with ErrAdminServerNotConfigured, if the Client is not configured with an AdminServer URL.
+func (c *Client) AdminServerCommand(server, command string) (map[string]interface{}, error) {
+	if c.adminServer == nil {
+		return nil, ErrAdminServerNotConfigured
+	}
+
+	return c.adminServer.command(server, command)
+}
+
+// serverStatus queries the given server via the AdminServer commands
+// `monitor`, `server_stats`, `connections` and `configuration`.
+func (a *adminServer) serverStatus(server string) *ServerStatus {
+	status := &ServerStatus{Server: server}
+
+	responses := make(map[string]map[string]interface{})
+	for _, command := range []string{"monitor", "server_stats", "connections", "configuration"} {
+		response, err := a.command(server, command)
+		switch {
+		case errors.Is(err, ErrAdminServerUnauthorized):
+			status.NotAllowedCommands = append(status.NotAllowedCommands, command)
+		case err != nil:
+			status.Error = err
+			return status
+		default:
+			responses[command] = response
+		}
+	}
+
+	if monitor, found := responses["monitor"]; found {
+		// NOTE: Same variables reported by `mntr`, without the `zk_` prefix
+		status.Monitoring = make(map[string]string, len(monitor))
+		for key, value := range jsonStrings(monitor) {
+			status.Monitoring["zk_"+key] = value
+		}
+		if err := status.setFromMntr(status.Monitoring); err != nil {
+			status.Error = fmt.Errorf("invalid 'monitor' response from '%s': %w", server, err)
+			return status
+		}
+	}
+
+	if stats, found := responses["server_stats"]; found {
+		if err := status.setFromServerStats(stats); err != nil {
+			status.Error = fmt.Errorf("invalid 'server_stats' response from '%s': %w", server, err)
+			return status
+		}
+	}
+
+	if configuration, found := responses["configuration"]; found {
+		status.Configuration = jsonStrings(configuration)
+	}
+
+	if connections, found := responses["connections"]; found {
+		for _, key := range []string{"connections", "secure_connections"} {
+			list, _ := connections[key].([]interface{})
+			for _, connection := range list {
+				status.ClientConnections = append(status.ClientConnections, jsonString(connection))
+			}
+		}
+	}
+
+	return status
+}
+
+// serverHealth queries the given server via the AdminServer commands `ruok` and `server_stats`.
+func (a *adminServer) serverHealth(server string) *ServerHealth {
+	health := &ServerHealth{Server: server}
+
+	if _, err := a.command(server, "ruok"); err != nil {
+		health.Error = err
+		return health
+	}
+
+	stats, err := a.command(server, "server_stats")
+	if err != nil {
+		health.Error = err
+		return health
+	}
+
+	status := &ServerStatus{}
+	if err = status.setFromServerStats(stats); err != nil {
+		health.Error = fmt.Errorf("invalid 'server_stats' response from '%s': %w", server, err)
+		return health
+	}
+	if status.Mode == "" {
+		health.Error = fmt.Errorf("server '%s' is unhealthy: %w", server, ErrServerNotServing)
+		return health
+	}
+	health.Mode, health.OutstandingRequests = status.Mode, status.OutstandingRequests
+
+	return health
+}
+
+func (s *ServerStatus) setFromServerStats(response map[string]interface{}) error {
+	stats, _ := response["server_stats"].(map[string]interface{})
+
+	s.Mode = jsonString(stats["server_state"])
+	s.Version, _, _ = strings.Cut(jsonString(response["version"]), ",")
+
+	if zxid := jsonString(stats["last_processed_zxid"]); zxid != "" {
+		parsed, err := strconv.ParseInt(zxid, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: '%s'", ErrUnexpectedCommandResponse, zxid)
+		}
+		s.Zxid = parsed
+	}
+
+	var err error
+	if s.AvgLatency, err = parseFloatValue(jsonString(stats["avg_latency"])); err != nil {
+		return err
+	}
+
+	return parseIntValues([]intValue{
+		{jsonString(stats["min_latency"]), &s.MinLatency},
+		{jsonString(stats["max_latency"]), &s.MaxLatency},
+		{jsonString(stats["num_alive_client_connections"]), &s.Connections},
+		{jsonString(stats["outstanding_requests"]), &s.OutstandingRequests},
+		{jsonString(response["node_count"]), &s.NodeCount},
+	})
+}
+
+// jsonStrings converts the values of an AdminServer response into strings (see jsonString),
+// leaving out the `command` and `error` fields that are part of every response.
+func jsonStrings(response map[string]interface{}) map[string]string {
+	values := make(map[string]string, len(response))
+	for key, value := range response {
+		if key != "command" && key != "error" {
+			values[key] = jsonString(value)
+		}
+	}
+
+	return values
+}
+
+// jsonString converts a JSON value into a string: scalars as-is (null as empty string),
+// while arrays and objects are JSON encoded.
+func jsonString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	}
+}
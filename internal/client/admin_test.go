@@ -0,0 +1,118 @@
+package client_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	testifyRequire "github.com/stretchr/testify/require"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+// startAdminServer starts a fake ZooKeeper AdminServer, that responds to the given commands,
+// and returns its URL (i.e. with the AdminServerHostPlaceholder).
+// The `configuration` command requires digest authentication as `admin:secret`.
+func startAdminServer(t *testing.T, responses map[string]string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		command := r.URL.Path[len("/commands/"):]
+		if command == "configuration" && r.Header.Get("Authorization") != "digest admin:secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		response, found := responses[command]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			response = `{"command":"` + command + `","error":"Unknown command: ` + command + `"}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	testifyRequire.NoError(t, err)
+
+	return "http://" + client.AdminServerHostPlaceholder + ":" + port + "/commands/"
+}
+
+func TestValidateAdminServerURL(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	assert.NoError(client.ValidateAdminServerURL("https://{host}:8080/commands"))
+	assert.NoError(client.ValidateAdminServerURL("http://zk-admin.example.com/commands"))
+	assert.ErrorIs(
+		client.ValidateAdminServerURL("ftp://{host}/commands"),
+		client.ErrInvalidAdminServerURL,
+	)
+	assert.ErrorIs(client.ValidateAdminServerURL("{host}:8080"), client.ErrInvalidAdminServerURL)
+}
+
+func TestAdminServerStatus(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	adminServerURL := startAdminServer(t, map[string]string{
+		"ruok": `{"command":"ruok","error":null}`,
+		"monitor": `{"version":"3.9.2-e454e8c, built on 2024-02-12 20:59 UTC","avg_latency":0.5,` +
+			`"max_latency":12,"min_latency":0,"znode_count":10,"num_alive_connections":3,` +
+			`"outstanding_requests":0,"server_state":"leader","synced_followers":2,"command":"monitor","error":null}`,
+		"server_stats": `{"version":"3.9.2-e454e8c, built on 2024-02-12 20:59 UTC","read_only":false,` +
+			`"server_stats":{"last_processed_zxid":4294967314,"outstanding_requests":1,"server_state":"leader",` +
+			`"avg_latency":0.5,"max_latency":12,"min_latency":0,"num_alive_client_connections":3},` +
+			`"node_count":10,"command":"server_stats","error":null}`,
+		"connections": `{"connections":[{"remote_socket_address":"10.0.0.1:51234","outstanding_requests":0}],` +
+			`"secure_connections":[],"command":"connections","error":null}`,
+		"configuration": `{"client_port":2181,"data_dir":"/data","server_id":1,"command":"configuration","error":null}`,
+	})
+
+	server := startFourLetterWordServer(t, map[string]string{})
+	zkClient, err := client.NewClient(&client.Config{
+		Servers:           server,
+		SessionTimeoutSec: client.DefaultZooKeeperSessionSec,
+		AdminServerURL:    adminServerURL,
+	})
+	require.NoError(err)
+	defer zkClient.Close()
+
+	// `configuration` requires authentication
+	status := zkClient.ServerStatus(server)
+	require.NoError(status.Error)
+	assert.Equal(client.ServerModeLeader, status.Mode)
+	assert.Equal(int64(0x100000012), status.Zxid)
+	assert.InDelta(0.5, status.AvgLatency, 0.0001)
+	assert.Equal(12, status.MaxLatency)
+	assert.Equal(10, status.NodeCount)
+	assert.Equal(3, status.Connections)
+	// `server_stats` takes precedence over `monitor`
+	assert.Equal(1, status.OutstandingRequests)
+	assert.Equal("3.9.2-e454e8c", status.Version)
+	assert.Equal("2", status.Monitoring["zk_synced_followers"])
+	assert.Equal(
+		[]string{`{"outstanding_requests":0,"remote_socket_address":"10.0.0.1:51234"}`},
+		status.ClientConnections,
+	)
+	assert.Empty(status.Configuration)
+	assert.Equal([]string{"configuration"}, status.NotAllowedCommands)
+
+	health, violations := zkClient.CheckHealth(&client.HealthThresholds{})
+	assert.Empty(violations)
+	require.Len(health, 1)
+	assert.Equal(client.ServerModeLeader, health[0].Mode)
+
+	_, err = zkClient.AdminServerCommand(server, "does_not_exist")
+	var commandErr *client.AdminServerCommandError
+	require.ErrorAs(err, &commandErr)
+	assert.ErrorContains(err, "Unknown command: does_not_exist")
+}
+
+func TestAdminServerNotConfigured(t *testing.T) {
+	server := startFourLetterWordServer(t, map[string]string{})
+	zkClient := newFourLetterWordTestClient(t, server)
+
+	_, err := zkClient.AdminServerCommand(server, "monitor")
+	testifyRequire.ErrorIs(t, err, client.ErrAdminServerNotConfigured)
+}
@@ -211,3 +211,170 @@ func TestFailureWithNonExistingZNodes(t *testing.T) {
 	require.Error(err)
 	assert.Equal("failed to update ZNode '/also-does-not-exist': does not exist", err.Error())
 }
+
+func TestSyncRead(t *testing.T) {
+	client, assert, require := initTest(t)
+	defer client.Close()
+
+	znode, err := client.Create("/test/SyncRead", []byte("data"), zk.WorldACL(zk.PermAll))
+	require.NoError(err)
+
+	syncedZNode, err := client.SyncRead("/test/SyncRead")
+	require.NoError(err)
+	assert.Equal(znode.Data, syncedZNode.Data)
+	assert.Equal(znode.Stat.Mzxid, syncedZNode.Stat.Mzxid)
+
+	_, err = client.SyncRead("/test/does-not-exist")
+	require.ErrorIs(err, zk.ErrNoNode)
+
+	err = client.Delete("/test")
+	require.NoError(err)
+}
+
+func TestWaitForPropagation(t *testing.T) {
+	t.Setenv(client.EnvZooKeeperWaitForPropagation, "true")
+	t.Setenv(client.EnvZooKeeperPropagationTimeoutSec, "5")
+	propClient, assert, require := initTest(t)
+	defer propClient.Close()
+
+	znode, err := propClient.Create(
+		"/test/WaitForPropagation",
+		[]byte("data"),
+		zk.WorldACL(zk.PermAll),
+	)
+	require.NoError(err)
+	require.NoError(propClient.WaitForPropagation(znode))
+
+	znode, err = propClient.Update(
+		"/test/WaitForPropagation",
+		[]byte("new data"),
+		zk.WorldACL(zk.PermAll),
+	)
+	require.NoError(err)
+	require.NoError(propClient.WaitForPropagation(znode))
+
+	// a write that never happened can't propagate
+	neverWritten := *znode
+	neverWritten.Stat = &zk.Stat{Mzxid: znode.Stat.Mzxid + 1_000_000}
+	err = propClient.WaitForPropagation(&neverWritten)
+	var timeoutErr *client.PropagationTimeoutError
+	require.ErrorAs(err, &timeoutErr)
+	assert.ErrorContains(err, "/test/WaitForPropagation")
+
+	require.NoError(propClient.Delete("/test"))
+	require.NoError(propClient.WaitForDeletion("/test/WaitForPropagation"))
+	require.NoError(propClient.WaitForDeletion("/test"))
+}
+
+func TestChroot(t *testing.T) {
+	t.Setenv(client.EnvZooKeeperChroot, "/chroot-test/app")
+	chrootClient, assert, require := initTest(t)
+	defer chrootClient.Close()
+
+	// create, relative to the chroot (that is created as well)
+	znode, err := chrootClient.Create("/config/node", []byte("data"), zk.WorldACL(zk.PermAll))
+	require.NoError(err)
+	assert.Equal("/config/node", znode.Path)
+
+	seqZNode, err := chrootClient.CreateSequential(
+		"/config/seq-",
+		[]byte("seq"),
+		zk.WorldACL(zk.PermAll),
+	)
+	require.NoError(err)
+	assert.Equal("/config/seq-0000000001", seqZNode.Path)
+
+	// confirm it's at the right absolute path
+	t.Setenv(client.EnvZooKeeperChroot, "")
+	rootClient, err := client.NewClientFromEnv()
+	require.NoError(err)
+	defer rootClient.Close()
+
+	znode, err = rootClient.Read("/chroot-test/app/config/node")
+	require.NoError(err)
+	assert.Equal([]byte("data"), znode.Data)
+
+	// read the chroot itself
+	znode, err = chrootClient.Read("/")
+	require.NoError(err)
+	assert.Equal("/", znode.Path)
+
+	// delete, relative to the chroot
+	err = chrootClient.Delete("/config")
+	require.NoError(err)
+
+	znodeExists, err := rootClient.Exists("/chroot-test/app/config")
+	require.NoError(err)
+	assert.False(znodeExists)
+
+	// Cleanup
+	err = rootClient.Delete("/chroot-test")
+	require.NoError(err)
+}
+
+func TestParseConnectString(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	servers, chroot := client.ParseConnectString("zk-01:2181,zk-02:2181")
+	assert.Equal([]string{"zk-01:2181", "zk-02:2181"}, servers)
+	assert.Empty(chroot)
+
+	servers, chroot = client.ParseConnectString("zk-01:2181,zk-02:2181/app/namespace/")
+	assert.Equal([]string{"zk-01:2181", "zk-02:2181"}, servers)
+	assert.Equal("/app/namespace", chroot)
+
+	servers, chroot = client.ParseConnectString("[::1]:2181/")
+	assert.Equal([]string{"[::1]:2181"}, servers)
+	assert.Empty(chroot)
+}
+
+func TestNewClientCredentialsValidation(t *testing.T) {
+	require := testifyRequire.New(t)
+
+	// NOTE: Credentials are validated before connecting, so no ZooKeeper is needed
+	_, err := client.NewClient(&client.Config{
+		Servers:           "localhost:2181",
+		SessionTimeoutSec: 10,
+		Username:          "user",
+	})
+	require.ErrorIs(err, client.ErrUserPassBothOrNone)
+
+	_, err = client.NewClient(&client.Config{
+		Servers:           "localhost:2181",
+		SessionTimeoutSec: 10,
+		SuperuserUsername: "super",
+	})
+	require.ErrorIs(err, client.ErrSuperuserWithoutPassword)
+}
+
+func TestDeleteUnusedParents(t *testing.T) {
+	client, assert, require := initTest(t)
+	defer client.Close()
+
+	znode, err := client.Create("/test/DeleteUnusedParents/a/b", nil, zk.WorldACL(zk.PermAll))
+	require.NoError(err)
+	assert.Equal(
+		[]string{"/test", "/test/DeleteUnusedParents", "/test/DeleteUnusedParents/a"},
+		znode.CreatedParents,
+	)
+
+	// Parents in use by others are not deleted
+	sibling, err := client.Create("/test/DeleteUnusedParents/sibling", nil, zk.WorldACL(zk.PermAll))
+	require.NoError(err)
+	assert.Empty(sibling.CreatedParents)
+
+	require.NoError(client.Delete(znode.Path))
+	deleted, err := client.DeleteUnusedParents(znode.CreatedParents)
+	require.NoError(err)
+	assert.Equal([]string{"/test/DeleteUnusedParents/a"}, deleted)
+
+	// Parents modified since created are not deleted
+	require.NoError(client.Delete(sibling.Path))
+	_, err = client.Update("/test/DeleteUnusedParents", []byte("in use"), zk.WorldACL(zk.PermAll))
+	require.NoError(err)
+	deleted, err = client.DeleteUnusedParents(znode.CreatedParents)
+	require.NoError(err)
+	assert.Empty(deleted)
+
+	require.NoError(client.Delete("/test"))
+}
@@ -0,0 +1,322 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Setting identifies a boolean or integer setting of a Config, that can be explicitly set to its zero-value
+// (see Config.ExplicitSettings). Settings are bit flags, so that they can be combined.
+type Setting uint32
+
+// Boolean and integer settings of a Config.
+const (
+	SettingSessionTimeout Setting = 1 << iota
+	SettingMaxBufferSize
+	SettingConnectTimeout
+	SettingMaxReconnectAttempts
+	SettingSyncReads
+	SettingAllowReadOnly
+	SettingWaitForPropagation
+	SettingPropagationTimeout
+	SettingHealthCheck
+	SettingHealthCheckMinQuorum
+	SettingHealthCheckMaxOutstandingRequests
+	SettingTLSEnabled
+	SettingTLSSkipVerify
+)
+
+// Config holds all the settings necessary to construct a Client.
+//
+// It can be assembled from multiple sources (ex. environment variables,
+// client configuration file), that are layered on top of each other via Merge.
+type Config struct {
+	Servers           string
+	ServersSRV        string
+	SessionTimeoutSec int
+	Username          string
+	Password          string
+	Chroot            string
+	ProxyURL          string
+	TLS               TLSOptions
+	// AdminServerURL makes the Client use the AdminServer, instead of four letter words
+	// (see ValidateAdminServerURL).
+	AdminServerURL string
+	// SuperuserUsername and SuperuserPassword are used, instead of Username and Password,
+	// for the operations that require the ZooKeeper superuser (ex. IncrementalReconfig).
+	SuperuserUsername string
+	SuperuserPassword string
+
+	// Connection tuning
+	MaxBufferSize        int
+	ConnectTimeoutSec    int
+	MaxReconnectAttempts int
+	ServerOrder          string
+
+	// SyncReads makes every Client.Read equivalent to a Client.SyncRead.
+	SyncReads bool
+	// AllowReadOnly allows connecting to servers in read-only mode (i.e. `canBeReadOnly`).
+	AllowReadOnly bool
+	// WaitForPropagation makes Client.WaitForPropagation and Client.WaitForDeletion wait,
+	// up to PropagationTimeoutSec, for writes to reach every server.
+	WaitForPropagation    bool
+	PropagationTimeoutSec int
+
+	// HealthCheck requires the ensemble to satisfy the HealthThresholds before being used.
+	// Checking it is up to the user of the Client (see Client.CheckHealth).
+	HealthCheck      bool
+	HealthThresholds HealthThresholds
+
+	// ExplicitSettings marks the boolean and integer settings that are set, even if to their zero-value
+	// (ex. SyncReads set to `false`, to override a connection profile setting it to `true`).
+	// It only matters to Merge: it's not part of the merged Config.
+	ExplicitSettings Setting
+}
+
+// IsExplicit reports if the given Setting is marked in ExplicitSettings.
+func (cfg *Config) IsExplicit(setting Setting) bool {
+	return cfg.ExplicitSettings&setting != 0
+}
+
+// Merge overlays onto this Config all the settings that are set in other:
+// strings that are not empty, and booleans and integers that are either not zero-value,
+// or marked in the ExplicitSettings of other.
+//
+// Servers and ServersSRV are treated as a single setting, as they are mutually exclusive:
+// setting either of them in other replaces both.
+func (cfg *Config) Merge(other *Config) {
+	if other.Servers != "" || other.ServersSRV != "" {
+		cfg.Servers, cfg.ServersSRV = other.Servers, other.ServersSRV
+	}
+	mergeInt(
+		&cfg.SessionTimeoutSec,
+		other.SessionTimeoutSec,
+		other.IsExplicit(SettingSessionTimeout),
+	)
+	mergeString(&cfg.Username, other.Username)
+	mergeString(&cfg.Password, other.Password)
+	mergeString(&cfg.Chroot, other.Chroot)
+	mergeString(&cfg.ProxyURL, other.ProxyURL)
+	mergeString(&cfg.AdminServerURL, other.AdminServerURL)
+	mergeString(&cfg.SuperuserUsername, other.SuperuserUsername)
+	mergeString(&cfg.SuperuserPassword, other.SuperuserPassword)
+	mergeInt(&cfg.MaxBufferSize, other.MaxBufferSize, other.IsExplicit(SettingMaxBufferSize))
+	mergeInt(
+		&cfg.ConnectTimeoutSec,
+		other.ConnectTimeoutSec,
+		other.IsExplicit(SettingConnectTimeout),
+	)
+	mergeInt(
+		&cfg.MaxReconnectAttempts,
+		other.MaxReconnectAttempts,
+		other.IsExplicit(SettingMaxReconnectAttempts),
+	)
+	mergeString(&cfg.ServerOrder, other.ServerOrder)
+	mergeBool(&cfg.SyncReads, other.SyncReads, other.IsExplicit(SettingSyncReads))
+	mergeBool(&cfg.AllowReadOnly, other.AllowReadOnly, other.IsExplicit(SettingAllowReadOnly))
+	mergeBool(
+		&cfg.WaitForPropagation,
+		other.WaitForPropagation,
+		other.IsExplicit(SettingWaitForPropagation),
+	)
+	mergeInt(
+		&cfg.PropagationTimeoutSec,
+		other.PropagationTimeoutSec,
+		other.IsExplicit(SettingPropagationTimeout),
+	)
+	mergeBool(&cfg.HealthCheck, other.HealthCheck, other.IsExplicit(SettingHealthCheck))
+	mergeInt(
+		&cfg.HealthThresholds.MinQuorumSize,
+		other.HealthThresholds.MinQuorumSize,
+		other.IsExplicit(SettingHealthCheckMinQuorum),
+	)
+	mergeInt(
+		&cfg.HealthThresholds.MaxOutstandingRequests,
+		other.HealthThresholds.MaxOutstandingRequests,
+		other.IsExplicit(SettingHealthCheckMaxOutstandingRequests),
+	)
+
+	mergeBool(&cfg.TLS.IsEnabled, other.TLS.IsEnabled, other.IsExplicit(SettingTLSEnabled))
+	mergeBool(&cfg.TLS.SkipVerify, other.TLS.SkipVerify, other.IsExplicit(SettingTLSSkipVerify))
+	mergeString(&cfg.TLS.CAFile, other.TLS.CAFile)
+	mergeString(&cfg.TLS.CertFile, other.TLS.CertFile)
+	mergeString(&cfg.TLS.KeyFile, other.TLS.KeyFile)
+	mergeString(&cfg.TLS.KeyPassword, other.TLS.KeyPassword)
+	mergeString(&cfg.TLS.KeyStoreFile, other.TLS.KeyStoreFile)
+	mergeString(&cfg.TLS.KeyStorePassword, other.TLS.KeyStorePassword)
+	mergeString(&cfg.TLS.KeyStoreType, other.TLS.KeyStoreType)
+	mergeString(&cfg.TLS.TrustStoreFile, other.TLS.TrustStoreFile)
+	mergeString(&cfg.TLS.TrustStorePassword, other.TLS.TrustStorePassword)
+	mergeString(&cfg.TLS.TrustStoreType, other.TLS.TrustStoreType)
+}
+
+func mergeString(dst *string, src string) {
+	if src != "" {
+		*dst = src
+	}
+}
+
+func mergeInt(dst *int, src int, explicit bool) {
+	if explicit || src != 0 {
+		*dst = src
+	}
+}
+
+func mergeBool(dst *bool, src bool, explicit bool) {
+	if explicit || src {
+		*dst = src
+	}
+}
+
+// ConfigSources lists the configuration sources that can be layered underneath
+// the explicitly provided settings. All of them are optional.
+type ConfigSources struct {
+	// ClientConfigFile is the path to a ZooKeeper Java client configuration file.
+	ClientConfigFile string
+	// ProfilesFile is the path to the connection profiles file.
+	// If empty, DefaultProfilesFilePath is used.
+	ProfilesFile string
+	// Profile is the name of the connection profile to use.
+	Profile string
+}
+
+// ResolveConfig layers all the configuration sources, in order of increasing precedence:
+//
+//  1. the ZooKeeper Java client configuration file
+//  2. the connection profile
+//  3. the explicit settings
+//
+// Defaults are then applied to whatever setting is left unset.
+func ResolveConfig(sources *ConfigSources, explicit *Config) (*Config, error) {
+	cfg := &Config{}
+
+	if sources.ClientConfigFile != "" {
+		fileCfg, err := LoadClientConfigFile(sources.ClientConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Merge(fileCfg)
+	}
+
+	if sources.Profile != "" {
+		profilesFile := sources.ProfilesFile
+		if profilesFile == "" {
+			var err error
+			if profilesFile, err = DefaultProfilesFilePath(); err != nil {
+				return nil, err
+			}
+		}
+
+		profileCfg, err := LoadProfile(profilesFile, sources.Profile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Merge(profileCfg)
+	}
+
+	cfg.Merge(explicit)
+
+	if cfg.SessionTimeoutSec == 0 {
+		cfg.SessionTimeoutSec = DefaultZooKeeperSessionSec
+	}
+
+	return cfg, nil
+}
+
+// ConfigFromEnv constructs a *Config from environment variables.
+//
+// The configuration sources (see ResolveConfig) are set via EnvZooKeeperClientConfigFile,
+// EnvZooKeeperProfilesFile and EnvZooKeeperProfile, while all the other environment variables
+// provide the explicit settings.
+func ConfigFromEnv() (*Config, error) {
+	envCfg := &Config{
+		Servers:            os.Getenv(EnvZooKeeperServer),
+		ServersSRV:         os.Getenv(EnvZooKeeperServersSRV),
+		Username:           os.Getenv(EnvZooKeeperUsername),
+		Password:           os.Getenv(EnvZooKeeperPassword),
+		Chroot:             os.Getenv(EnvZooKeeperChroot),
+		ProxyURL:           os.Getenv(EnvZooKeeperProxyURL),
+		AdminServerURL:     os.Getenv(EnvZooKeeperAdminServerURL),
+		SuperuserUsername:  os.Getenv(EnvZooKeeperSuperuserUsername),
+		SuperuserPassword:  os.Getenv(EnvZooKeeperSuperuserPassword),
+		ServerOrder:        os.Getenv(EnvZooKeeperServerOrder),
+		SyncReads:          os.Getenv(EnvZooKeeperSyncReads) == "true",
+		AllowReadOnly:      os.Getenv(EnvZooKeeperAllowReadOnly) == "true",
+		WaitForPropagation: os.Getenv(EnvZooKeeperWaitForPropagation) == "true",
+		HealthCheck:        os.Getenv(EnvZooKeeperHealthCheck) == "true",
+		TLS: TLSOptions{
+			IsEnabled:          os.Getenv(EnvZooKeeperTLSEnabled) == "true",
+			SkipVerify:         os.Getenv(EnvZooKeeperTLSSkipVerify) == "true",
+			CAFile:             os.Getenv(EnvZooKeeperTLSCAFile),
+			CertFile:           os.Getenv(EnvZooKeeperTLSCertFile),
+			KeyFile:            os.Getenv(EnvZooKeeperTLSKeyFile),
+			KeyPassword:        os.Getenv(EnvZooKeeperTLSKeyPassword),
+			KeyStoreFile:       os.Getenv(EnvZooKeeperTLSKeyStoreFile),
+			KeyStorePassword:   os.Getenv(EnvZooKeeperTLSKeyStorePassword),
+			KeyStoreType:       os.Getenv(EnvZooKeeperTLSKeyStoreType),
+			TrustStoreFile:     os.Getenv(EnvZooKeeperTLSTrustStoreFile),
+			TrustStorePassword: os.Getenv(EnvZooKeeperTLSTrustStorePassword),
+			TrustStoreType:     os.Getenv(EnvZooKeeperTLSTrustStoreType),
+		},
+	}
+
+	// NOTE: Boolean settings set to anything (ex. `false`) are explicit
+	for envVar, setting := range map[string]Setting{
+		EnvZooKeeperSyncReads:          SettingSyncReads,
+		EnvZooKeeperAllowReadOnly:      SettingAllowReadOnly,
+		EnvZooKeeperWaitForPropagation: SettingWaitForPropagation,
+		EnvZooKeeperHealthCheck:        SettingHealthCheck,
+		EnvZooKeeperTLSEnabled:         SettingTLSEnabled,
+		EnvZooKeeperTLSSkipVerify:      SettingTLSSkipVerify,
+	} {
+		if os.Getenv(envVar) != "" {
+			envCfg.ExplicitSettings |= setting
+		}
+	}
+
+	for _, intEnv := range []struct {
+		envVar  string
+		dst     *int
+		setting Setting
+	}{
+		{EnvZooKeeperSessionSec, &envCfg.SessionTimeoutSec, SettingSessionTimeout},
+		{EnvZooKeeperMaxBufferSize, &envCfg.MaxBufferSize, SettingMaxBufferSize},
+		{EnvZooKeeperConnectTimeoutSec, &envCfg.ConnectTimeoutSec, SettingConnectTimeout},
+		{
+			EnvZooKeeperMaxReconnectAttempts,
+			&envCfg.MaxReconnectAttempts,
+			SettingMaxReconnectAttempts,
+		},
+		{
+			EnvZooKeeperPropagationTimeoutSec,
+			&envCfg.PropagationTimeoutSec,
+			SettingPropagationTimeout,
+		},
+		{
+			EnvZooKeeperHealthCheckMinQuorum,
+			&envCfg.HealthThresholds.MinQuorumSize,
+			SettingHealthCheckMinQuorum,
+		},
+		{
+			EnvZooKeeperHealthCheckMaxOutstandingRequests,
+			&envCfg.HealthThresholds.MaxOutstandingRequests,
+			SettingHealthCheckMaxOutstandingRequests,
+		},
+	} {
+		if value, ok := os.LookupEnv(intEnv.envVar); ok {
+			valueInt, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert '%s' to integer: %w", value, err)
+			}
+			*intEnv.dst = valueInt
+			envCfg.ExplicitSettings |= intEnv.setting
+		}
+	}
+
+	return ResolveConfig(&ConfigSources{
+		ClientConfigFile: os.Getenv(EnvZooKeeperClientConfigFile),
+		ProfilesFile:     os.Getenv(EnvZooKeeperProfilesFile),
+		Profile:          os.Getenv(EnvZooKeeperProfile),
+	}, envCfg)
+}
@@ -0,0 +1,313 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// CopyOptions are the options of a copy of a subtree (see Client.DiffCopy and Client.SyncCopy).
+type CopyOptions struct {
+	// Include are glob patterns (see path.Match) of the paths, relative to the source, of the descendants to copy,
+	// together with their descendants and ancestors. If empty, all the descendants are copied.
+	Include []string
+	// Exclude are glob patterns (see path.Match) of the paths, relative to the source, of the descendants
+	// not to copy, together with their descendants. Takes precedence over Include.
+	Exclude []string
+	// ACL replaces the ACL of each ZNode copied, if set. Otherwise, the ACL is copied from the source.
+	ACL []zk.ACL
+	// Prune deletes the descendants of the copy that don't exist in the source,
+	// unless they are filtered out by Include or Exclude.
+	Prune bool
+}
+
+// CopyDiff is the difference between a subtree (i.e. the source) and its copy.
+type CopyDiff struct {
+	// SourceDigest is a digest of the paths (relative to the source), data and ACL to copy:
+	// it changes when the source changes.
+	SourceDigest string
+	// Paths are the paths of the ZNodes of the copy, each before its children.
+	Paths []string
+	// Missing are the paths of the ZNodes of the copy that don't exist, each before its children.
+	Missing []string
+	// Changed are the paths of the ZNodes of the copy whose data or ACL differ from the source.
+	Changed []string
+	// Extra are the paths of the descendants of the copy that don't exist in the source, deepest first.
+	// Only if CopyOptions.Prune is set.
+	Extra []string
+}
+
+// InSync returns true if the copy doesn't differ from the source.
+func (d *CopyDiff) InSync() bool {
+	return len(d.Missing) == 0 && len(d.Changed) == 0 && len(d.Extra) == 0
+}
+
+// Drifted returns the paths of the ZNodes of the copy that differ from the source
+// (i.e. missing, changed or extra), sorted.
+func (d *CopyDiff) Drifted() []string {
+	drifted := make([]string, 0, len(d.Missing)+len(d.Changed)+len(d.Extra))
+	drifted = append(drifted, d.Missing...)
+	drifted = append(drifted, d.Changed...)
+	drifted = append(drifted, d.Extra...)
+	slices.Sort(drifted)
+
+	return drifted
+}
+
+// DiffCopy compares the subtree rooted at the ZNode at srcPath, filtered according to the given CopyOptions,
+// with its copy at dstPath.
+//
+// Fails if the source doesn't exist, while the copy doesn't need to exist (i.e. it's all missing).
+func (c *Client) DiffCopy(srcPath, dstPath string, opts *CopyOptions) (*CopyDiff, error) {
+	diff, _, err := c.diffCopy(srcPath, dstPath, opts)
+
+	return diff, err
+}
+
+// SyncCopy copies the subtree rooted at the ZNode at srcPath, filtered according to the given CopyOptions,
+// to dstPath: only the ZNodes of the copy that differ from the source are written, and any necessary parent
+// of dstPath is created. Ephemeral ZNodes are copied as persistent ZNodes, as their owner session can't be copied.
+//
+// Returns the ZNode at dstPath, with the parents created (if any), and the difference that was synced.
+func (c *Client) SyncCopy(srcPath, dstPath string, opts *CopyOptions) (*ZNode, *CopyDiff, error) {
+	if c.IsReadOnly() {
+		return nil, nil, fmt.Errorf("failed to copy ZNode '%s': %w", srcPath, ErrReadOnlyMode)
+	}
+
+	diff, expected, err := c.diffCopy(srcPath, dstPath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	createdParents, err := c.createEmptyZNodes(
+		listParentsInOrder(c.absPath(dstPath)),
+		0,
+		expected[dstPath].ACL,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, missing := range diff.Missing {
+		_, err := c.zkConn.Create(
+			c.absPath(missing),
+			expected[missing].Data,
+			0,
+			expected[missing].ACL,
+		)
+		if err = translateReadOnlyError(err); err != nil {
+			return nil, nil, fmt.Errorf("failed to copy ZNode to '%s': %w", missing, err)
+		}
+	}
+
+	for _, changed := range diff.Changed {
+		if _, err := c.Update(changed, expected[changed].Data, expected[changed].ACL); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, extra := range diff.Extra {
+		if err := c.Delete(extra); err != nil && !errors.Is(err, ErrZNodeDoesNotExist) {
+			return nil, nil, err
+		}
+	}
+
+	znode, err := c.Read(dstPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	znode.CreatedParents = c.relCreatedParents(createdParents)
+
+	return znode, diff, nil
+}
+
+// DeleteCopy deletes the ZNodes of a copy (as in CopyDiff.Paths, each before its children), deepest first,
+// keeping the ones that have children not part of the copy (ex. excluded from it, or created by someone else).
+//
+// Returns the ZNodes kept, deepest first.
+func (c *Client) DeleteCopy(paths []string) ([]string, error) {
+	if c.IsReadOnly() {
+		return nil, fmt.Errorf("failed to delete copy: %w", ErrReadOnlyMode)
+	}
+
+	var kept []string
+	for i := len(paths) - 1; i >= 0; i-- {
+		err := c.DeleteEmpty(paths[i])
+		if errors.Is(err, ErrZNodeHasChildren) {
+			kept = append(kept, paths[i])
+			continue
+		}
+		if err != nil && !errors.Is(err, ErrZNodeDoesNotExist) {
+			return kept, err
+		}
+	}
+
+	return kept, nil
+}
+
+// diffCopy returns the difference between the source and its copy, as well as the expected ZNodes of the copy,
+// by path.
+func (c *Client) diffCopy(
+	srcPath, dstPath string,
+	opts *CopyOptions,
+) (*CopyDiff, map[string]*ZNode, error) {
+	if isSameOrDescendant(dstPath, srcPath) || isSameOrDescendant(srcPath, dstPath) {
+		return nil, nil, NewCopyIntoItselfError(srcPath, dstPath)
+	}
+
+	sources, err := c.readSubtree(srcPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diff := &CopyDiff{}
+	expected := make(map[string]*ZNode)
+	digest := sha256.New()
+	for _, source := range filterCopy(sources, srcPath, opts) {
+		relPath := relativePath(source.Path, srcPath)
+		copyPath := joinRelativePath(dstPath, relPath)
+
+		acl := source.ACL
+		if len(opts.ACL) > 0 {
+			acl = opts.ACL
+		}
+
+		diff.Paths = append(diff.Paths, copyPath)
+		expected[copyPath] = &ZNode{Path: copyPath, Data: source.Data, ACL: acl}
+		fmt.Fprintf(digest, "%q %x %v\n", relPath, source.Data, acl)
+
+		copied, err := c.doRead(copyPath)
+		switch {
+		case errors.Is(err, ErrZNodeDoesNotExist):
+			diff.Missing = append(diff.Missing, copyPath)
+		case err != nil:
+			return nil, nil, err
+		case !bytes.Equal(copied.Data, source.Data) || !slices.Equal(copied.ACL, acl):
+			diff.Changed = append(diff.Changed, copyPath)
+		}
+	}
+	diff.SourceDigest = hex.EncodeToString(digest.Sum(nil))
+
+	if opts.Prune {
+		extra, err := c.listExtraCopies(dstPath, opts, expected)
+		if err != nil {
+			return nil, nil, err
+		}
+		diff.Extra = extra
+	}
+
+	return diff, expected, nil
+}
+
+// listExtraCopies lists the descendants of the copy at dstPath that are not expected, deepest first,
+// unless they are filtered out by the given CopyOptions.
+func (c *Client) listExtraCopies(
+	dstPath string,
+	opts *CopyOptions,
+	expected map[string]*ZNode,
+) ([]string, error) {
+	var extra []string
+	err := c.walkSubtree(dstPath, func(znodePath string, _ *zk.Stat) error {
+		if expected[znodePath] != nil {
+			return nil
+		}
+
+		// NOTE: Descendants of an extra ZNode are deleted with it
+		for _, extraPath := range extra {
+			if isSameOrDescendant(znodePath, extraPath) {
+				return nil
+			}
+		}
+
+		relPath := relativePath(znodePath, dstPath)
+		if !matchesAnyCopyPattern(relPath, opts.Exclude) &&
+			(len(opts.Include) == 0 || matchesAnyCopyPattern(relPath, opts.Include)) {
+			extra = append(extra, znodePath)
+		}
+
+		return nil
+	})
+	if errors.Is(err, ErrZNodeDoesNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(extra)
+
+	return extra, nil
+}
+
+// filterCopy returns the ZNodes of the subtree rooted at srcPath (see readSubtree) to copy,
+// according to the Include and Exclude patterns of the given CopyOptions.
+func filterCopy(sources []*ZNode, srcPath string, opts *CopyOptions) []*ZNode {
+	selected := make(map[string]bool)
+	for _, source := range sources {
+		relPath := relativePath(source.Path, srcPath)
+		if relPath == "" {
+			selected[relPath] = true
+			continue
+		}
+		if matchesAnyCopyPattern(relPath, opts.Exclude) ||
+			(len(opts.Include) > 0 && !matchesAnyCopyPattern(relPath, opts.Include)) {
+			continue
+		}
+
+		// NOTE: The ancestors are necessary to create the ZNode
+		for ancestor := relPath; ancestor != "."; ancestor = path.Dir(ancestor) {
+			selected[ancestor] = true
+		}
+	}
+
+	filtered := make([]*ZNode, 0, len(selected))
+	for _, source := range sources {
+		if selected[relativePath(source.Path, srcPath)] {
+			filtered = append(filtered, source)
+		}
+	}
+
+	return filtered
+}
+
+// matchesAnyCopyPattern returns true if the given relative path, or any of its ancestors,
+// matches any of the given glob patterns.
+func matchesAnyCopyPattern(relPath string, patterns []string) bool {
+	for ancestor := relPath; ancestor != "." && ancestor != ""; ancestor = path.Dir(ancestor) {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, ancestor); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isSameOrDescendant returns true if the ZNode at the given path is the given ancestor, or one of its descendants.
+func isSameOrDescendant(znodePath, ancestor string) bool {
+	return znodePath == ancestor || ancestor == zNodeRootPath ||
+		strings.HasPrefix(znodePath, ancestor+string(zNodePathSeparator))
+}
+
+// relativePath returns the path of the given descendant, relative to the given ancestor
+// (i.e. "" for the ancestor itself).
+func relativePath(descendant, ancestor string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(descendant, ancestor), string(zNodePathSeparator))
+}
+
+// joinRelativePath returns the absolute path of the given path, relative to the given ancestor.
+func joinRelativePath(ancestor, relPath string) string {
+	if relPath == "" {
+		return ancestor
+	}
+
+	return childPath(ancestor, relPath)
+}
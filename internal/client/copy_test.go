@@ -0,0 +1,124 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/go-zookeeper/zk"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func TestSyncCopy(t *testing.T) {
+	zkClient, assert, require := initTest(t)
+	defer zkClient.Close()
+
+	for path, data := range map[string]string{
+		"/test/SyncCopy/staging/app/db/url":      "jdbc:staging",
+		"/test/SyncCopy/staging/app/db/password": "secret",
+		"/test/SyncCopy/staging/app/features/x":  "on",
+		"/test/SyncCopy/staging/app/scratch/tmp": "tmp",
+	} {
+		_, err := zkClient.Create(path, []byte(data), zk.WorldACL(zk.PermAll))
+		require.NoError(err)
+	}
+	defer func() {
+		require.NoError(zkClient.Delete("/test"))
+	}()
+
+	acl := zk.WorldACL(zk.PermRead)
+	opts := &client.CopyOptions{
+		Include: []string{"db", "features"},
+		Exclude: []string{"*/password"},
+		ACL:     acl,
+		Prune:   true,
+	}
+
+	diff, err := zkClient.DiffCopy("/test/SyncCopy/staging/app", "/test/SyncCopy/prod/app", opts)
+	require.NoError(err)
+	assert.False(diff.InSync())
+	assert.Equal([]string{
+		"/test/SyncCopy/prod/app",
+		"/test/SyncCopy/prod/app/db",
+		"/test/SyncCopy/prod/app/db/url",
+		"/test/SyncCopy/prod/app/features",
+		"/test/SyncCopy/prod/app/features/x",
+	}, diff.Paths)
+	assert.Equal(diff.Paths, diff.Missing)
+
+	znode, diff, err := zkClient.SyncCopy(
+		"/test/SyncCopy/staging/app",
+		"/test/SyncCopy/prod/app",
+		opts,
+	)
+	require.NoError(err)
+	assert.Equal("/test/SyncCopy/prod/app", znode.Path)
+	assert.Equal([]string{"/test/SyncCopy/prod"}, znode.CreatedParents)
+	assert.Len(diff.Missing, 5)
+	sourceDigest := diff.SourceDigest
+
+	url, err := zkClient.Read("/test/SyncCopy/prod/app/db/url")
+	require.NoError(err)
+	assert.Equal([]byte("jdbc:staging"), url.Data)
+	assert.Equal(acl, url.ACL)
+
+	for _, path := range []string{"/test/SyncCopy/prod/app/db/password", "/test/SyncCopy/prod/app/scratch"} {
+		exists, err := zkClient.Exists(path)
+		require.NoError(err)
+		assert.False(exists)
+	}
+
+	diff, err = zkClient.DiffCopy("/test/SyncCopy/staging/app", "/test/SyncCopy/prod/app", opts)
+	require.NoError(err)
+	assert.True(diff.InSync())
+	assert.Equal(sourceDigest, diff.SourceDigest)
+
+	// Drift on both sides: the source changes, while the copy gets an extra ZNode
+	_, err = zkClient.Update(
+		"/test/SyncCopy/staging/app/db/url",
+		[]byte("jdbc:prod"),
+		zk.WorldACL(zk.PermAll),
+	)
+	require.NoError(err)
+	_, err = zkClient.Create("/test/SyncCopy/prod/app/features/y", nil, zk.WorldACL(zk.PermAll))
+	require.NoError(err)
+	_, err = zkClient.Create("/test/SyncCopy/prod/app/db/password", nil, zk.WorldACL(zk.PermAll))
+	require.NoError(err)
+
+	diff, err = zkClient.DiffCopy("/test/SyncCopy/staging/app", "/test/SyncCopy/prod/app", opts)
+	require.NoError(err)
+	assert.NotEqual(sourceDigest, diff.SourceDigest)
+	assert.Equal([]string{"/test/SyncCopy/prod/app/db/url"}, diff.Changed)
+	assert.Equal([]string{"/test/SyncCopy/prod/app/features/y"}, diff.Extra)
+	assert.Equal([]string{
+		"/test/SyncCopy/prod/app/db/url",
+		"/test/SyncCopy/prod/app/features/y",
+	}, diff.Drifted())
+
+	_, _, err = zkClient.SyncCopy("/test/SyncCopy/staging/app", "/test/SyncCopy/prod/app", opts)
+	require.NoError(err)
+
+	diff, err = zkClient.DiffCopy("/test/SyncCopy/staging/app", "/test/SyncCopy/prod/app", opts)
+	require.NoError(err)
+	assert.True(diff.InSync())
+
+	// Excluded ZNodes of the copy are left untouched
+	exists, err := zkClient.Exists("/test/SyncCopy/prod/app/db/password")
+	require.NoError(err)
+	assert.True(exists)
+
+	// Deleting the copy keeps the ZNodes with children that are not part of it
+	kept, err := zkClient.DeleteCopy(diff.Paths)
+	require.NoError(err)
+	assert.Equal([]string{"/test/SyncCopy/prod/app/db", "/test/SyncCopy/prod/app"}, kept)
+	exists, err = zkClient.Exists("/test/SyncCopy/prod/app/features")
+	require.NoError(err)
+	assert.False(exists)
+	exists, err = zkClient.Exists("/test/SyncCopy/prod/app/db/password")
+	require.NoError(err)
+	assert.True(exists)
+
+	var copyIntoItselfErr *client.CopyIntoItselfError
+	_, err = zkClient.DiffCopy("/test/SyncCopy/staging", "/test/SyncCopy/staging/app", opts)
+	require.ErrorAs(err, &copyIntoItselfErr)
+	_, err = zkClient.DiffCopy("/test/SyncCopy/staging/app", "/test/SyncCopy/staging", opts)
+	require.ErrorAs(err, &copyIntoItselfErr)
+}
@@ -0,0 +1,265 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// EnsembleConfigPath is the path of the ZNode holding the dynamic configuration of the ensemble
+// (since ZooKeeper 3.5). It's not affected by the chroot.
+const EnsembleConfigPath = "/zookeeper/config"
+
+// Roles of the members of the ensemble.
+const (
+	MemberRoleParticipant = "participant"
+	MemberRoleObserver    = "observer"
+)
+
+// ErrEnsembleConfigNotAvailable returned when the ensemble doesn't provide a dynamic configuration
+// (i.e. ZooKeeper older than 3.5).
+var ErrEnsembleConfigNotAvailable = errors.New(
+	"dynamic configuration not available (requires ZooKeeper 3.5 or later)",
+)
+
+// EnsembleMember is a member of the ensemble, as listed in its dynamic configuration.
+type EnsembleMember struct {
+	ID           int64
+	Host         string
+	QuorumPort   int
+	ElectionPort int
+	// Role of the member (ex. MemberRoleParticipant).
+	Role string
+	// ClientAddress and ClientPort the member serves clients on. ClientPort is zero if the member doesn't serve
+	// clients, while ClientAddress is empty if it serves them on all its addresses.
+	ClientAddress string
+	ClientPort    int
+}
+
+// String returns the member in the format of the dynamic configuration
+// (ex. `server.1=zk-01:2888:3888:participant;2181`), as expected by reconfig.
+// Wildcard client addresses (ex. `0.0.0.0`) are omitted, as they are equivalent to an empty one.
+func (m *EnsembleMember) String() string {
+	member := fmt.Sprintf(
+		"server.%d=%s:%d:%d:%s",
+		m.ID,
+		bracketIPv6(m.Host),
+		m.QuorumPort,
+		m.ElectionPort,
+		m.Role,
+	)
+	if m.ClientPort != 0 {
+		clientAddr := strconv.Itoa(m.ClientPort)
+		if clientAddress := normalizeClientAddress(m.ClientAddress); clientAddress != "" {
+			clientAddr = net.JoinHostPort(clientAddress, clientAddr)
+		}
+		member += ";" + clientAddr
+	}
+
+	return member
+}
+
+// sameAddresses reports if the other member has the same addresses of this one (i.e. regardless of the role).
+// Wildcard client addresses (ex. `0.0.0.0`) are the same as an empty one.
+func (m *EnsembleMember) sameAddresses(other *EnsembleMember) bool {
+	return m.Host == other.Host &&
+		m.QuorumPort == other.QuorumPort &&
+		m.ElectionPort == other.ElectionPort &&
+		normalizeClientAddress(m.ClientAddress) == normalizeClientAddress(other.ClientAddress) &&
+		m.ClientPort == other.ClientPort
+}
+
+// ClientServer returns the `host:port` clients can connect to the member on, or an empty string
+// if the member doesn't serve clients. Wildcard client addresses (ex. `0.0.0.0`) are replaced with Host.
+func (m *EnsembleMember) ClientServer() string {
+	if m.ClientPort == 0 {
+		return ""
+	}
+
+	host := normalizeClientAddress(m.ClientAddress)
+	if host == "" {
+		host = m.Host
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(m.ClientPort))
+}
+
+// EnsembleConfig is the dynamic configuration of the ensemble.
+type EnsembleConfig struct {
+	// Members of the ensemble, sorted by ID.
+	Members []*EnsembleMember
+	// Version of the configuration (i.e. the zxid that created it).
+	Version int64
+	// Raw content of EnsembleConfigPath.
+	Raw string
+}
+
+// EnsembleConfig reads and parses the dynamic configuration of the ensemble (see EnsembleConfigPath).
+func (c *Client) EnsembleConfig() (*EnsembleConfig, error) {
+	if c.syncReads && !c.IsReadOnly() {
+		if _, err := c.zkConn.Sync(EnsembleConfigPath); err != nil &&
+			!errors.Is(err, zk.ErrNoNode) {
+			return nil, fmt.Errorf("failed to sync ZNode '%s': %w", EnsembleConfigPath, err)
+		}
+	}
+
+	// NOTE: The dynamic configuration is not subject to the chroot
+	data, _, err := c.zkConn.Get(EnsembleConfigPath)
+	if errors.Is(err, zk.ErrNoNode) {
+		return nil, ErrEnsembleConfigNotAvailable
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ZNode '%s': %w", EnsembleConfigPath, err)
+	}
+
+	return ParseEnsembleConfig(string(data))
+}
+
+// ParseEnsembleConfig parses the dynamic configuration of the ensemble.
+//
+// It's made of one line per member, in the format
+// `server.<id>=<host>:<quorum port>:<election port>[:<role>][;[<client address>:]<client port>]`,
+// followed by the version of the configuration (in hex format). For example:
+//
+//	server.1=zk-01:2888:3888:participant;0.0.0.0:2181
+//	server.2=zk-02:2888:3888:observer;2181
+//	version=100000000
+func ParseEnsembleConfig(raw string) (*EnsembleConfig, error) {
+	cfg := &EnsembleConfig{Raw: raw}
+
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, NewInvalidEnsembleConfigError(line, "expected 'key=value'")
+		}
+
+		switch {
+		case key == "version":
+			version, err := strconv.ParseInt(value, 16, 64)
+			if err != nil {
+				return nil, NewInvalidEnsembleConfigError(line, "version is not in hex format")
+			}
+			cfg.Version = version
+		case strings.HasPrefix(key, "server."):
+			member, err := parseEnsembleMember(line, strings.TrimPrefix(key, "server."), value)
+			if err != nil {
+				return nil, err
+			}
+			cfg.Members = append(cfg.Members, member)
+		default:
+			// NOTE: Ignore any other key, that future versions of ZooKeeper might add
+		}
+	}
+
+	sort.Slice(cfg.Members, func(i, j int) bool {
+		return cfg.Members[i].ID < cfg.Members[j].ID
+	})
+
+	return cfg, nil
+}
+
+func parseEnsembleMember(line, id, value string) (*EnsembleMember, error) {
+	member := &EnsembleMember{Role: MemberRoleParticipant}
+
+	var err error
+	if member.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return nil, NewInvalidEnsembleConfigError(line, fmt.Sprintf("invalid server id '%s'", id))
+	}
+
+	serverAddr, clientAddr, _ := strings.Cut(value, ";")
+
+	// <host>:<quorum port>:<election port>[:<role>], where host might be an IPv6 address in brackets
+	var parts []string
+	if strings.HasPrefix(serverAddr, "[") {
+		host, rest, found := strings.Cut(serverAddr[1:], "]")
+		if !found {
+			return nil, NewInvalidEnsembleConfigError(
+				line,
+				fmt.Sprintf("unbalanced brackets in '%s'", serverAddr),
+			)
+		}
+		parts = append([]string{host}, strings.Split(strings.TrimPrefix(rest, ":"), ":")...)
+	} else {
+		parts = strings.Split(serverAddr, ":")
+	}
+	if len(parts) < 3 || len(parts) > 4 {
+		return nil, NewInvalidEnsembleConfigError(
+			line,
+			"expected '<host>:<quorum port>:<election port>[:<role>]'",
+		)
+	}
+
+	member.Host = parts[0]
+	if member.QuorumPort, err = strconv.Atoi(parts[1]); err != nil {
+		return nil, NewInvalidEnsembleConfigError(
+			line,
+			fmt.Sprintf("invalid quorum port '%s'", parts[1]),
+		)
+	}
+	if member.ElectionPort, err = strconv.Atoi(parts[2]); err != nil {
+		return nil, NewInvalidEnsembleConfigError(
+			line,
+			fmt.Sprintf("invalid election port '%s'", parts[2]),
+		)
+	}
+	if len(parts) == 4 {
+		member.Role = parts[3]
+	}
+	if member.Role != MemberRoleParticipant && member.Role != MemberRoleObserver {
+		return nil, NewInvalidEnsembleConfigError(
+			line,
+			fmt.Sprintf("unknown role '%s'", member.Role),
+		)
+	}
+
+	// [<client address>:]<client port>
+	if clientAddr != "" {
+		clientPort := clientAddr
+		if strings.Contains(clientAddr, ":") {
+			if member.ClientAddress, clientPort, err = net.SplitHostPort(clientAddr); err != nil {
+				return nil, NewInvalidEnsembleConfigError(
+					line,
+					fmt.Sprintf("invalid client address '%s'", clientAddr),
+				)
+			}
+			member.ClientAddress = normalizeClientAddress(member.ClientAddress)
+		}
+		if member.ClientPort, err = strconv.Atoi(clientPort); err != nil {
+			return nil, NewInvalidEnsembleConfigError(
+				line,
+				fmt.Sprintf("invalid client port '%s'", clientPort),
+			)
+		}
+	}
+
+	return member, nil
+}
+
+// normalizeClientAddress replaces wildcard client addresses (ex. `0.0.0.0` or `::`) with an empty one,
+// as both mean that the member serves clients on all its addresses.
+func normalizeClientAddress(address string) string {
+	if ip := net.ParseIP(address); ip != nil && ip.IsUnspecified() {
+		return ""
+	}
+
+	return address
+}
+
+// bracketIPv6 encloses the given host in brackets, if it's an IPv6 address.
+func bracketIPv6(host string) string {
+	if strings.Contains(host, ":") {
+		return "[" + host + "]"
+	}
+
+	return host
+}
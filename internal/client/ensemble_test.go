@@ -0,0 +1,74 @@
+package client_test
+
+import (
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	testifyRequire "github.com/stretchr/testify/require"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func TestParseEnsembleConfig(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	raw := `server.3=[fd00::3]:2888:3888:observer;[fd00::3]:2181
+server.1=zk-01:2888:3888:participant;0.0.0.0:2181
+server.2=zk-02:2888:3888;2181
+server.4=zk-04:2888:3888:participant
+version=100000012
+`
+	cfg, err := client.ParseEnsembleConfig(raw)
+	require.NoError(err)
+
+	assert.Equal(int64(0x100000012), cfg.Version)
+	assert.Equal(raw, cfg.Raw)
+	require.Len(cfg.Members, 4)
+
+	assert.Equal(&client.EnsembleMember{
+		ID:           1,
+		Host:         "zk-01",
+		QuorumPort:   2888,
+		ElectionPort: 3888,
+		Role:         client.MemberRoleParticipant,
+		ClientPort:   2181,
+	}, cfg.Members[0])
+	assert.Equal("zk-01:2181", cfg.Members[0].ClientServer())
+	assert.Equal("server.1=zk-01:2888:3888:participant;2181", cfg.Members[0].String())
+
+	assert.Equal(client.MemberRoleParticipant, cfg.Members[1].Role)
+	assert.Empty(cfg.Members[1].ClientAddress)
+	assert.Equal("zk-02:2181", cfg.Members[1].ClientServer())
+	assert.Equal("server.2=zk-02:2888:3888:participant;2181", cfg.Members[1].String())
+
+	assert.Equal("fd00::3", cfg.Members[2].Host)
+	assert.Equal(client.MemberRoleObserver, cfg.Members[2].Role)
+	assert.Equal("[fd00::3]:2181", cfg.Members[2].ClientServer())
+	assert.Equal("server.3=[fd00::3]:2888:3888:observer;[fd00::3]:2181", cfg.Members[2].String())
+
+	assert.Zero(cfg.Members[3].ClientPort)
+	assert.Empty(cfg.Members[3].ClientServer())
+	assert.Equal("server.4=zk-04:2888:3888:participant", cfg.Members[3].String())
+
+	// Standalone servers have an empty dynamic configuration
+	cfg, err = client.ParseEnsembleConfig("")
+	require.NoError(err)
+	assert.Empty(cfg.Members)
+}
+
+func TestParseEnsembleConfigErrors(t *testing.T) {
+	var invalidErr *client.InvalidEnsembleConfigError
+
+	for _, raw := range []string{
+		"server.1",
+		"server.x=zk-01:2888:3888",
+		"server.1=zk-01:2888",
+		"server.1=zk-01:quorum:3888",
+		"server.1=zk-01:2888:3888:leader",
+		"server.1=[fd00::1:2888:3888",
+		"server.1=zk-01:2888:3888;zk-01:port",
+		"version=xyz",
+	} {
+		_, err := client.ParseEnsembleConfig(raw)
+		testifyRequire.ErrorAs(t, err, &invalidErr, raw)
+	}
+}
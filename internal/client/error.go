@@ -2,6 +2,8 @@ package client
 
 import (
 	"fmt"
+	"strings"
+	"time"
 )
 
 // MissingEnvVarError returned when a necessary Environment variable is missing.
@@ -69,3 +71,516 @@ func (e *CannotUpdateDoesNotExistError) Error() string {
 func NewCannotUpdateDoesNotExistError(path string) *CannotUpdateDoesNotExistError {
 	return &CannotUpdateDoesNotExistError{path}
 }
+
+// UnsupportedStoreTypeError returned when a TLS KeyStore/TrustStore type is not supported.
+type UnsupportedStoreTypeError struct {
+	storeType string
+}
+
+func (e *UnsupportedStoreTypeError) Error() string {
+	return fmt.Sprintf(
+		"unsupported TLS keystore/truststore type '%s' (supported: %v)",
+		e.storeType,
+		SupportedStoreTypes(),
+	)
+}
+
+// NewUnsupportedStoreTypeError creates a new UnsupportedStoreTypeError.
+//
+// storeType is the type (or file extension) that could not be mapped to a supported type.
+//
+// Example:
+//
+//	NewUnsupportedStoreTypeError("BCFKS")
+func NewUnsupportedStoreTypeError(storeType string) *UnsupportedStoreTypeError {
+	return &UnsupportedStoreTypeError{storeType}
+}
+
+// MalformedPropertyEscapeError returned when a `.properties` file contains a malformed `\uXXXX` escape.
+type MalformedPropertyEscapeError struct {
+	value string
+}
+
+func (e *MalformedPropertyEscapeError) Error() string {
+	return fmt.Sprintf("malformed \\uXXXX escape sequence in '%s'", e.value)
+}
+
+// NewMalformedPropertyEscapeError creates a new MalformedPropertyEscapeError.
+//
+// value is the key or value of the property containing the malformed escape.
+//
+// Example:
+//
+//	NewMalformedPropertyEscapeError(`zookeeper.connect=\u00`)
+func NewMalformedPropertyEscapeError(value string) *MalformedPropertyEscapeError {
+	return &MalformedPropertyEscapeError{value}
+}
+
+// ProfileNotFoundError returned when the requested connection profile is not defined in the profiles file.
+type ProfileNotFoundError struct {
+	profilesFile string
+	profile      string
+}
+
+func (e *ProfileNotFoundError) Error() string {
+	return fmt.Sprintf("profile '%s' not found in profiles file '%s'", e.profile, e.profilesFile)
+}
+
+// NewProfileNotFoundError creates a new ProfileNotFoundError.
+//
+// profilesFile is the path to the profiles file, and profile is the name of the missing profile.
+//
+// Example:
+//
+//	NewProfileNotFoundError("/home/user/.zookeeper/profiles.toml", "prod")
+func NewProfileNotFoundError(profilesFile, profile string) *ProfileNotFoundError {
+	return &ProfileNotFoundError{profilesFile, profile}
+}
+
+// UnknownProfileKeyError returned when the profiles file contains a key that is not supported.
+type UnknownProfileKeyError struct {
+	profilesFile string
+	key          string
+}
+
+func (e *UnknownProfileKeyError) Error() string {
+	return fmt.Sprintf("unknown key '%s' in profiles file '%s'", e.key, e.profilesFile)
+}
+
+// NewUnknownProfileKeyError creates a new UnknownProfileKeyError.
+//
+// profilesFile is the path to the profiles file, and key is the (fully qualified) unsupported key.
+//
+// Example:
+//
+//	NewUnknownProfileKeyError("/home/user/.zookeeper/profiles.toml", "prod.tls.enable")
+func NewUnknownProfileKeyError(profilesFile, key string) *UnknownProfileKeyError {
+	return &UnknownProfileKeyError{profilesFile, key}
+}
+
+// InvalidChrootError returned when the chroot is not an absolute ZNode path.
+type InvalidChrootError struct {
+	chroot string
+}
+
+func (e *InvalidChrootError) Error() string {
+	return fmt.Sprintf("invalid chroot '%s': must start with '%c'", e.chroot, zNodePathSeparator)
+}
+
+// NewInvalidChrootError creates a new InvalidChrootError.
+//
+// chroot is the invalid chroot.
+//
+// Example:
+//
+//	NewInvalidChrootError("app/namespace")
+func NewInvalidChrootError(chroot string) *InvalidChrootError {
+	return &InvalidChrootError{chroot}
+}
+
+// ConflictingChrootError returned when the connect string contains a chroot suffix
+// that differs from the explicitly configured chroot.
+type ConflictingChrootError struct {
+	connectStringChroot string
+	chroot              string
+}
+
+func (e *ConflictingChrootError) Error() string {
+	return fmt.Sprintf(
+		"chroot '%s' conflicts with chroot '%s' in the servers connect string",
+		e.chroot,
+		e.connectStringChroot,
+	)
+}
+
+// NewConflictingChrootError creates a new ConflictingChrootError.
+//
+// connectStringChroot is the chroot suffix of the connect string, and chroot is the configured chroot.
+//
+// Example:
+//
+//	NewConflictingChrootError("/app", "/other")
+func NewConflictingChrootError(connectStringChroot, chroot string) *ConflictingChrootError {
+	return &ConflictingChrootError{connectStringChroot, chroot}
+}
+
+// InvalidServerError returned when a ZooKeeper server entry is not in the format `host[:port]`.
+type InvalidServerError struct {
+	server string
+	reason string
+}
+
+func (e *InvalidServerError) Error() string {
+	return fmt.Sprintf("invalid ZooKeeper server '%s': %s", e.server, e.reason)
+}
+
+// NewInvalidServerError creates a new InvalidServerError.
+//
+// server is the invalid server entry, and reason describes what is wrong with it.
+//
+// Example:
+//
+//	NewInvalidServerError("::1:2181", "IPv6 addresses must be enclosed in brackets")
+func NewInvalidServerError(server, reason string) *InvalidServerError {
+	return &InvalidServerError{server, reason}
+}
+
+// NoServersFoundError returned when a DNS SRV record doesn't list any ZooKeeper server.
+type NoServersFoundError struct {
+	srvName string
+}
+
+func (e *NoServersFoundError) Error() string {
+	return fmt.Sprintf("no ZooKeeper servers found in DNS SRV record '%s'", e.srvName)
+}
+
+// NewNoServersFoundError creates a new NoServersFoundError.
+//
+// srvName is the name of the DNS SRV record.
+//
+// Example:
+//
+//	NewNoServersFoundError("_zookeeper._tcp.example.com")
+func NewNoServersFoundError(srvName string) *NoServersFoundError {
+	return &NoServersFoundError{srvName}
+}
+
+// NoServersResolvedError returned when none of the ZooKeeper servers hostnames can be resolved.
+type NoServersResolvedError struct {
+	servers []string
+}
+
+func (e *NoServersResolvedError) Error() string {
+	return fmt.Sprintf("unable to resolve any of the ZooKeeper servers %q", e.servers)
+}
+
+// NewNoServersResolvedError creates a new NoServersResolvedError.
+//
+// servers are the ZooKeeper servers, in the format `host:port`.
+//
+// Example:
+//
+//	NewNoServersResolvedError([]string{"zk-01:2181", "zk-02:2181"})
+func NewNoServersResolvedError(servers []string) *NoServersResolvedError {
+	return &NoServersResolvedError{servers}
+}
+
+// UnsupportedServerOrderError returned when the order in which servers are connected to is not supported.
+type UnsupportedServerOrderError struct {
+	serverOrder string
+}
+
+func (e *UnsupportedServerOrderError) Error() string {
+	return fmt.Sprintf(
+		"unsupported server order '%s' (supported: %v)",
+		e.serverOrder,
+		SupportedServerOrders(),
+	)
+}
+
+// NewUnsupportedServerOrderError creates a new UnsupportedServerOrderError.
+//
+// serverOrder is the unsupported order.
+//
+// Example:
+//
+//	NewUnsupportedServerOrderError("round-robin")
+func NewUnsupportedServerOrderError(serverOrder string) *UnsupportedServerOrderError {
+	return &UnsupportedServerOrderError{serverOrder}
+}
+
+// PropagationTimeoutError returned when a write doesn't reach every server of the ensemble in time.
+type PropagationTimeoutError struct {
+	path    string
+	timeout time.Duration
+	lagging []string
+}
+
+func (e *PropagationTimeoutError) Error() string {
+	return fmt.Sprintf(
+		"write to ZNode '%s' did not propagate to all servers within %s: lagging %v",
+		e.path,
+		e.timeout,
+		e.lagging,
+	)
+}
+
+// NewPropagationTimeoutError creates a new PropagationTimeoutError.
+//
+// path is the path of the ZNode written, timeout is how long it was waited for,
+// and lagging describes the servers that the write has not reached.
+//
+// Example:
+//
+//	NewPropagationTimeoutError("/path/to/znode", 30*time.Second, []string{"zk-02:2181 (write not propagated yet)"})
+func NewPropagationTimeoutError(
+	path string,
+	timeout time.Duration,
+	lagging []string,
+) *PropagationTimeoutError {
+	return &PropagationTimeoutError{path, timeout, lagging}
+}
+
+// QuorumTooSmallError returned when the ensemble has fewer healthy voting servers than required.
+type QuorumTooSmallError struct {
+	healthy  int
+	required int
+}
+
+func (e *QuorumTooSmallError) Error() string {
+	return fmt.Sprintf(
+		"only %d healthy voting server(s) in the ensemble, at least %d required",
+		e.healthy,
+		e.required,
+	)
+}
+
+// NewQuorumTooSmallError creates a new QuorumTooSmallError.
+//
+// healthy is the number of healthy voting servers, and required is the minimum number of them.
+//
+// Example:
+//
+//	NewQuorumTooSmallError(1, 2)
+func NewQuorumTooSmallError(healthy, required int) *QuorumTooSmallError {
+	return &QuorumTooSmallError{healthy, required}
+}
+
+// TooManyOutstandingRequestsError returned when a server has more outstanding requests than allowed.
+type TooManyOutstandingRequestsError struct {
+	server      string
+	outstanding int
+	maxAllowed  int
+}
+
+func (e *TooManyOutstandingRequestsError) Error() string {
+	return fmt.Sprintf(
+		"server '%s' has %d outstanding requests, at most %d allowed",
+		e.server,
+		e.outstanding,
+		e.maxAllowed,
+	)
+}
+
+// NewTooManyOutstandingRequestsError creates a new TooManyOutstandingRequestsError.
+//
+// server is the server, outstanding is its number of outstanding requests,
+// and maxAllowed is the maximum allowed.
+//
+// Example:
+//
+//	NewTooManyOutstandingRequestsError("zk-01:2181", 120, 100)
+func NewTooManyOutstandingRequestsError(
+	server string,
+	outstanding, maxAllowed int,
+) *TooManyOutstandingRequestsError {
+	return &TooManyOutstandingRequestsError{server, outstanding, maxAllowed}
+}
+
+// AdminServerCommandError returned when the AdminServer fails to execute a command.
+type AdminServerCommandError struct {
+	server  string
+	command string
+	message string
+}
+
+func (e *AdminServerCommandError) Error() string {
+	return fmt.Sprintf(
+		"AdminServer of '%s' failed to execute '%s': %s",
+		e.server,
+		e.command,
+		e.message,
+	)
+}
+
+// NewAdminServerCommandError creates a new AdminServerCommandError.
+//
+// server is the server, command is the AdminServer command,
+// and message is the error reported by the AdminServer (or the HTTP status).
+//
+// Example:
+//
+//	NewAdminServerCommandError("zk-01:2181", "monitor", "This ZooKeeper instance is not currently serving requests")
+func NewAdminServerCommandError(server, command, message string) *AdminServerCommandError {
+	return &AdminServerCommandError{server, command, message}
+}
+
+// InvalidEnsembleConfigError returned when the dynamic configuration of the ensemble can't be parsed.
+type InvalidEnsembleConfigError struct {
+	line   string
+	reason string
+}
+
+func (e *InvalidEnsembleConfigError) Error() string {
+	return fmt.Sprintf("invalid dynamic configuration line '%s': %s", e.line, e.reason)
+}
+
+// NewInvalidEnsembleConfigError creates a new InvalidEnsembleConfigError.
+//
+// line is the line of the dynamic configuration, and reason describes what's wrong with it.
+//
+// Example:
+//
+//	NewInvalidEnsembleConfigError("server.1=zk-01:2888", "expected '<host>:<quorum port>:<election port>[:<role>]'")
+func NewInvalidEnsembleConfigError(line, reason string) *InvalidEnsembleConfigError {
+	return &InvalidEnsembleConfigError{line, reason}
+}
+
+// ReconfigLosesQuorumError returned when a reconfiguration of the ensemble would lose quorum.
+type ReconfigLosesQuorumError struct {
+	reason string
+}
+
+func (e *ReconfigLosesQuorumError) Error() string {
+	return "reconfiguration would lose quorum: " + e.reason
+}
+
+// NewReconfigLosesQuorumError creates a new ReconfigLosesQuorumError.
+//
+// reason describes why the reconfiguration would lose quorum.
+//
+// Example:
+//
+//	NewReconfigLosesQuorumError("only 1 of the 3 current participants would stay participants")
+func NewReconfigLosesQuorumError(reason string) *ReconfigLosesQuorumError {
+	return &ReconfigLosesQuorumError{reason}
+}
+
+// InvalidQuotaError returned when the quota stored by ZooKeeper for a ZNode cannot be parsed.
+type InvalidQuotaError struct {
+	raw    string
+	reason string
+}
+
+func (e *InvalidQuotaError) Error() string {
+	return fmt.Sprintf("invalid quota '%s': %s", e.raw, e.reason)
+}
+
+// NewInvalidQuotaError creates a new InvalidQuotaError.
+//
+// raw is the quota as stored by ZooKeeper, and reason explains why it's invalid.
+//
+// Example:
+//
+//	NewInvalidQuotaError("count=ten", "'count' is not an integer")
+func NewInvalidQuotaError(raw, reason string) *InvalidQuotaError {
+	return &InvalidQuotaError{raw, reason}
+}
+
+// NestedQuotaError returned when setting a quota on a ZNode with an ancestor or a descendant that has a quota set.
+type NestedQuotaError struct {
+	path     string
+	existing string
+}
+
+func (e *NestedQuotaError) Error() string {
+	return fmt.Sprintf(
+		"cannot set quota of ZNode '%s': ZNode '%s' already has a quota set, and quotas cannot be nested",
+		e.path,
+		e.existing,
+	)
+}
+
+// NewNestedQuotaError creates a new NestedQuotaError.
+//
+// path is the ZNode the quota was being set on, and existing is the (absolute) path
+// of the ancestor or descendant with a quota already set.
+//
+// Example:
+//
+//	NewNestedQuotaError("/app/config", "/app")
+func NewNestedQuotaError(path, existing string) *NestedQuotaError {
+	return &NestedQuotaError{path, existing}
+}
+
+// CopyIntoItselfError returned when attempting to copy a ZNode to itself, or to one of its descendants.
+type CopyIntoItselfError struct {
+	srcPath string
+	dstPath string
+}
+
+func (e *CopyIntoItselfError) Error() string {
+	return fmt.Sprintf(
+		"cannot copy ZNode '%s' to '%s': it would copy into itself",
+		e.srcPath,
+		e.dstPath,
+	)
+}
+
+// NewCopyIntoItselfError creates a new CopyIntoItselfError.
+//
+// srcPath is the ZNode to copy, and dstPath is where it was going to be copied.
+//
+// Example:
+//
+//	NewCopyIntoItselfError("/app", "/app/backup")
+func NewCopyIntoItselfError(srcPath, dstPath string) *CopyIntoItselfError {
+	return &CopyIntoItselfError{srcPath, dstPath}
+}
+
+// EphemeralZNodesError returned when a subtree contains ephemeral ZNodes (see Client.CheckNoEphemerals).
+type EphemeralZNodesError struct {
+	path       string
+	ephemerals []*EphemeralZNode
+}
+
+func (e *EphemeralZNodesError) Error() string {
+	ephemerals := make([]string, 0, len(e.ephemerals))
+	for _, ephemeral := range e.ephemerals {
+		ephemerals = append(
+			ephemerals,
+			fmt.Sprintf("'%s' (session %s)", ephemeral.Path, ephemeral.OwnerHex()),
+		)
+	}
+
+	return fmt.Sprintf(
+		"subtree of ZNode '%s' contains %d ephemeral ZNode(s), owned by live sessions: %s",
+		e.path,
+		len(e.ephemerals),
+		strings.Join(ephemerals, ", "),
+	)
+}
+
+// Ephemerals returns the ephemeral ZNodes of the subtree.
+func (e *EphemeralZNodesError) Ephemerals() []*EphemeralZNode {
+	return e.ephemerals
+}
+
+// NewEphemeralZNodesError creates a new EphemeralZNodesError.
+//
+// path is the ZNode at the root of the subtree, and ephemerals are the ephemeral ZNodes found in it.
+//
+// Example:
+//
+//	NewEphemeralZNodesError("/app", []*EphemeralZNode{{Path: "/app/lock", Owner: 0x100000012}})
+func NewEphemeralZNodesError(path string, ephemerals []*EphemeralZNode) *EphemeralZNodesError {
+	return &EphemeralZNodesError{path, ephemerals}
+}
+
+// WaitTimeoutError returned when a ZNode doesn't satisfy a WaitCondition in time (see Client.WaitFor).
+type WaitTimeoutError struct {
+	path    string
+	timeout time.Duration
+	unmet   string
+}
+
+func (e *WaitTimeoutError) Error() string {
+	return fmt.Sprintf(
+		"ZNode '%s' did not satisfy the condition within %s: %s",
+		e.path,
+		e.timeout,
+		e.unmet,
+	)
+}
+
+// NewWaitTimeoutError creates a new WaitTimeoutError.
+//
+// path is the path of the ZNode waited for, timeout is how long it was waited for,
+// and unmet describes the condition that was last found unmet.
+//
+// Example:
+//
+//	NewWaitTimeoutError("/app/workers", 5*time.Minute, "it has 2 children, instead of at least 3")
+func NewWaitTimeoutError(path string, timeout time.Duration, unmet string) *WaitTimeoutError {
+	return &WaitTimeoutError{path, timeout, unmet}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+var (
+	// ErrFourLetterWordNotAllowed returned when a server refuses to execute a four letter word command,
+	// because it's not listed in its `4lw.commands.whitelist`.
+	ErrFourLetterWordNotAllowed = errors.New(
+		"four letter word command not in the server whitelist (see '4lw.commands.whitelist')",
+	)
+
+	// ErrUnexpectedCommandResponse returned when the response of a four letter word
+	// (or AdminServer) command can't be parsed.
+	ErrUnexpectedCommandResponse = errors.New("unexpected command response")
+)
+
+// fourLetterWordTimeout is the default timeout to execute a four letter word command.
+const fourLetterWordTimeout = 5 * time.Second
+
+// fourLetterWordNotAllowedSuffix is how ZooKeeper ends the response to a command that is not whitelisted.
+const fourLetterWordNotAllowedSuffix = "is not executed because it is not in the whitelist."
+
+// Servers returns the servers of the ensemble, as resolved when the Client was created
+// (i.e. in the `host:port` format, after DNS SRV discovery).
+func (c *Client) Servers() []string {
+	return zk.FormatServers(c.servers)
+}
+
+// FourLetterWord sends the given four letter word command (ex. `ruok`, `srvr`, `mntr`)
+// to the given server, and returns its response.
+//
+// The command is sent over a dedicated connection, that honours the same TLS and proxy
+// settings of the Client. Commands not whitelisted by the server fail with ErrFourLetterWordNotAllowed.
+//
+// See: https://zookeeper.apache.org/doc/current/zookeeperAdmin.html#sc_4lw.
+func (c *Client) FourLetterWord(server, command string) (string, error) {
+	conn, err := c.dial("tcp", server, fourLetterWordTimeout)
+	if err != nil {
+		return "", fmt.Errorf("failed to send '%s' to '%s': %w", command, server, err)
+	}
+
+	// NOTE: The server closes the connection once the response is sent
+	defer func() { _ = conn.Close() }()
+
+	if err = conn.SetDeadline(time.Now().Add(fourLetterWordTimeout)); err != nil {
+		return "", fmt.Errorf("failed to send '%s' to '%s': %w", command, server, err)
+	}
+
+	if _, err = conn.Write([]byte(command)); err != nil {
+		return "", fmt.Errorf("failed to send '%s' to '%s': %w", command, server, err)
+	}
+
+	response, err := io.ReadAll(conn)
+	if err != nil {
+		return "", fmt.Errorf("failed to read '%s' response from '%s': %w", command, server, err)
+	}
+
+	if strings.HasSuffix(strings.TrimSpace(string(response)), fourLetterWordNotAllowedSuffix) {
+		return "", fmt.Errorf(
+			"failed to send '%s' to '%s': %w",
+			command,
+			server,
+			ErrFourLetterWordNotAllowed,
+		)
+	}
+
+	return string(response), nil
+}
+
+// parseFourLetterWordResponse parses the response of four letter word commands
+// that are made of `key<separator>value` lines (ex. `srvr`, `mntr`, `conf`).
+//
+// Lines without separator are ignored.
+func parseFourLetterWordResponse(response, separator string) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(response, "\n") {
+		key, value, found := strings.Cut(line, separator)
+		if !found {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return values
+}
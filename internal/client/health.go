@@ -0,0 +1,177 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Modes of a ZooKeeper server, as reported by the `srvr` and `mntr` four letter words.
+const (
+	ServerModeLeader     = "leader"
+	ServerModeFollower   = "follower"
+	ServerModeObserver   = "observer"
+	ServerModeStandalone = "standalone"
+	ServerModeReadOnly   = "read-only"
+)
+
+var (
+	// ErrServerNotOK returned when a server doesn't respond `imok` to `ruok`.
+	ErrServerNotOK = errors.New("server did not respond 'imok' to 'ruok'")
+
+	// ErrServerNotServing returned when a server is not currently serving requests (ex. during leader election).
+	ErrServerNotServing = errors.New("server is not currently serving requests")
+
+	// ErrNoLeader returned when none of the servers is the leader of the ensemble.
+	ErrNoLeader = errors.New("none of the servers is the leader of the ensemble")
+)
+
+// HealthThresholds are the thresholds CheckHealth uses to decide if the ensemble is healthy.
+type HealthThresholds struct {
+	// MinQuorumSize is the minimum number of healthy voting servers (i.e. leader or followers).
+	// If zero, a majority of the servers is required.
+	MinQuorumSize int
+	// MaxOutstandingRequests is the maximum number of outstanding requests of each server.
+	// If zero, it is not checked.
+	MaxOutstandingRequests int
+}
+
+// ServerHealth is the health of a single server of the ensemble.
+type ServerHealth struct {
+	Server string
+	// Mode of the server (ex. ServerModeLeader). Empty if the server could not be queried.
+	Mode                string
+	OutstandingRequests int
+	// Error encountered querying the server, if any: the server is then considered unhealthy.
+	Error error
+}
+
+func (h *ServerHealth) isVoting() bool {
+	return h.Error == nil &&
+		(h.Mode == ServerModeLeader || h.Mode == ServerModeFollower || h.Mode == ServerModeStandalone)
+}
+
+func (h *ServerHealth) isLeader() bool {
+	return h.Error == nil && (h.Mode == ServerModeLeader || h.Mode == ServerModeStandalone)
+}
+
+// CheckHealth queries each server of the ensemble via the four letter words `ruok`, `srvr` and `mntr`,
+// and checks that:
+//
+//   - the number of healthy voting servers is at least HealthThresholds.MinQuorumSize
+//   - one of the servers is the leader (or a standalone server)
+//   - no server has more than HealthThresholds.MaxOutstandingRequests outstanding requests
+//
+// Only `srvr` is mandatory, as it's the only command whitelisted by default:
+// `ruok` and `mntr` are used only if the servers allow them.
+// If the Client is configured with an AdminServer URL, the AdminServer commands `ruok` and `server_stats`
+// are used instead.
+//
+// It returns the health of each server, and an error for each of the checks that failed.
+func (c *Client) CheckHealth(thresholds *HealthThresholds) ([]*ServerHealth, []error) {
+	servers := c.Servers()
+
+	health := make([]*ServerHealth, len(servers))
+	var wg sync.WaitGroup
+	for i, server := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			health[i] = c.serverHealth(server)
+		}()
+	}
+	wg.Wait()
+
+	minQuorumSize := thresholds.MinQuorumSize
+	if minQuorumSize == 0 {
+		minQuorumSize = len(servers)/2 + 1
+	}
+
+	var violations []error
+	voting, leaders := 0, 0
+	for _, h := range health {
+		if h.isVoting() {
+			voting++
+		}
+		if h.isLeader() {
+			leaders++
+		}
+		if thresholds.MaxOutstandingRequests > 0 &&
+			h.OutstandingRequests > thresholds.MaxOutstandingRequests {
+			violations = append(violations, NewTooManyOutstandingRequestsError(
+				h.Server,
+				h.OutstandingRequests,
+				thresholds.MaxOutstandingRequests,
+			))
+		}
+	}
+
+	if voting < minQuorumSize {
+		violations = append(violations, NewQuorumTooSmallError(voting, minQuorumSize))
+	}
+	if leaders == 0 {
+		violations = append(violations, ErrNoLeader)
+	}
+
+	return health, violations
+}
+
+func (c *Client) serverHealth(server string) *ServerHealth {
+	if c.adminServer != nil {
+		return c.adminServer.serverHealth(server)
+	}
+
+	health := &ServerHealth{Server: server}
+
+	ruok, err := c.FourLetterWord(server, "ruok")
+	switch {
+	case errors.Is(err, ErrFourLetterWordNotAllowed):
+		// Optional
+	case err != nil:
+		health.Error = err
+		return health
+	case strings.TrimSpace(ruok) != "imok":
+		health.Error = fmt.Errorf("server '%s' is unhealthy: %w", server, ErrServerNotOK)
+		return health
+	}
+
+	srvr, err := c.FourLetterWord(server, "srvr")
+	if err != nil {
+		health.Error = err
+		return health
+	}
+	srvrValues := parseFourLetterWordResponse(srvr, ":")
+	health.Mode = srvrValues["Mode"]
+	if health.Mode == "" {
+		health.Error = fmt.Errorf("server '%s' is unhealthy: %w", server, ErrServerNotServing)
+		return health
+	}
+	outstanding := srvrValues["Outstanding"]
+
+	mntr, err := c.FourLetterWord(server, "mntr")
+	switch {
+	case errors.Is(err, ErrFourLetterWordNotAllowed):
+		// Optional
+	case err != nil:
+		health.Error = err
+		return health
+	default:
+		if value, found := parseFourLetterWordResponse(mntr, "\t")["zk_outstanding_requests"]; found {
+			outstanding = value
+		}
+	}
+
+	if outstanding != "" {
+		if health.OutstandingRequests, err = strconv.Atoi(strings.TrimSpace(outstanding)); err != nil {
+			health.Error = fmt.Errorf(
+				"invalid outstanding requests from server '%s': %w",
+				server,
+				err,
+			)
+		}
+	}
+
+	return health
+}
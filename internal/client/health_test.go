@@ -0,0 +1,157 @@
+package client_test
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	testifyRequire "github.com/stretchr/testify/require"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+// startFourLetterWordServer starts a fake ZooKeeper server, that responds to the given four letter words.
+// Any other command is refused, as if not in the `4lw.commands.whitelist`.
+func startFourLetterWordServer(t *testing.T, responses map[string]string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	testifyRequire.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+
+				command := make([]byte, 4)
+				if _, err := io.ReadFull(conn, command); err != nil {
+					return
+				}
+
+				response, found := responses[string(command)]
+				if !found {
+					response = fmt.Sprintf(
+						"%s is not executed because it is not in the whitelist.\n",
+						command,
+					)
+				}
+				_, _ = conn.Write([]byte(response))
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func srvrResponse(mode string, outstanding string) string {
+	return strings.Join([]string{
+		"Zookeeper version: 3.8.4-9316c2a7a97e1666d8f4593f34dd6fc36ecc436c, built on 2024-02-12 22:16 UTC",
+		"Latency min/avg/max: 0/0.1/12",
+		"Received: 120",
+		"Sent: 119",
+		"Connections: 2",
+		"Outstanding: " + outstanding,
+		"Zxid: 0x100000012",
+		"Mode: " + mode,
+		"Node count: 8",
+	}, "\n") + "\n"
+}
+
+func newFourLetterWordTestClient(t *testing.T, servers ...string) *client.Client {
+	t.Helper()
+
+	zkClient, err := client.NewClient(&client.Config{
+		Servers:           strings.Join(servers, ","),
+		SessionTimeoutSec: client.DefaultZooKeeperSessionSec,
+	})
+	testifyRequire.NoError(t, err)
+	t.Cleanup(zkClient.Close)
+
+	return zkClient
+}
+
+func TestFourLetterWord(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	server := startFourLetterWordServer(t, map[string]string{"ruok": "imok"})
+	zkClient := newFourLetterWordTestClient(t, server)
+
+	assert.Equal([]string{server}, zkClient.Servers())
+
+	response, err := zkClient.FourLetterWord(server, "ruok")
+	require.NoError(err)
+	assert.Equal("imok", response)
+
+	_, err = zkClient.FourLetterWord(server, "mntr")
+	require.ErrorIs(err, client.ErrFourLetterWordNotAllowed)
+}
+
+func TestCheckHealth(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	// Only `srvr` is whitelisted
+	server := startFourLetterWordServer(t, map[string]string{
+		"srvr": srvrResponse("standalone", "7"),
+	})
+	zkClient := newFourLetterWordTestClient(t, server)
+
+	health, violations := zkClient.CheckHealth(&client.HealthThresholds{})
+	require.Empty(violations)
+	require.Len(health, 1)
+	require.NoError(health[0].Error)
+	assert.Equal(client.ServerModeStandalone, health[0].Mode)
+	assert.Equal(7, health[0].OutstandingRequests)
+
+	// All whitelisted: `mntr` takes precedence
+	server = startFourLetterWordServer(t, map[string]string{
+		"ruok": "imok",
+		"srvr": srvrResponse("standalone", "7"),
+		"mntr": "zk_version\t3.8.4\nzk_server_state\tstandalone\nzk_outstanding_requests\t120\n",
+	})
+	zkClient = newFourLetterWordTestClient(t, server)
+
+	health, violations = zkClient.CheckHealth(&client.HealthThresholds{MaxOutstandingRequests: 100})
+	require.Len(health, 1)
+	assert.Equal(120, health[0].OutstandingRequests)
+	require.Len(violations, 1)
+	var outstandingErr *client.TooManyOutstandingRequestsError
+	require.ErrorAs(violations[0], &outstandingErr)
+}
+
+func TestCheckHealthDegraded(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	follower := startFourLetterWordServer(t, map[string]string{
+		"srvr": srvrResponse("follower", "0"),
+	})
+	notServing := startFourLetterWordServer(t, map[string]string{
+		"srvr": "This ZooKeeper instance is not currently serving requests\n",
+	})
+	notOK := startFourLetterWordServer(t, map[string]string{
+		"ruok": "",
+		"srvr": srvrResponse("leader", "0"),
+	})
+	zkClient := newFourLetterWordTestClient(t, follower, notServing, notOK)
+
+	health, violations := zkClient.CheckHealth(&client.HealthThresholds{})
+	require.Len(health, 3)
+	assert.NoError(health[0].Error)
+	assert.ErrorIs(health[1].Error, client.ErrServerNotServing)
+	assert.ErrorIs(health[2].Error, client.ErrServerNotOK)
+
+	require.Len(violations, 2)
+	var quorumErr *client.QuorumTooSmallError
+	require.ErrorAs(violations[0], &quorumErr)
+	assert.ErrorContains(
+		quorumErr,
+		"only 1 healthy voting server(s) in the ensemble, at least 2 required",
+	)
+	require.ErrorIs(violations[1], client.ErrNoLeader)
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// dnsLookupTimeout is the maximum amount of time spent on each round of DNS lookups.
+const dnsLookupTimeout = 3 * time.Second
+
+// hostProvider is a zk.HostProvider that, unlike zk.DNSHostProvider, doesn't resolve
+// the servers hostnames only once: it re-resolves them every time it loops through
+// all the known servers without managing to connect.
+//
+// This ensures that the rolling replacement of the ZooKeeper servers
+// (ex. with new IP addresses) doesn't break long-running sessions.
+type hostProvider struct {
+	mu sync.Mutex
+
+	// srvName is the DNS SRV record that servers are (re-)discovered from, if set.
+	srvName string
+	// resolve is false when servers hostnames are resolved by a proxy, and so must be handed over as-is.
+	resolve bool
+	// ordered is true when servers must be tried in the given order, instead of randomly.
+	ordered bool
+
+	hosts   []string // Servers, in the format `host:port`
+	servers []string // Servers, resolved to the format `ip:port` (if resolve is true)
+	curr    int
+	last    int
+}
+
+var _ zk.HostProvider = (*hostProvider)(nil)
+
+// newHostProvider creates a new hostProvider for the given servers (in the format `host:port`).
+//
+// NOTE: The servers are provided here, and not via Init, because zk.Connect shuffles them.
+func newHostProvider(servers []string, srvName string, resolve, ordered bool) *hostProvider {
+	return &hostProvider{
+		hosts:   servers,
+		srvName: srvName,
+		resolve: resolve,
+		ordered: ordered,
+	}
+}
+
+// Init is called first, with the servers specified in the connection string
+// (ignored, in favour of the ones provided to newHostProvider).
+func (hp *hostProvider) Init(_ []string) error {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	resolved, err := hp.lookup()
+	if err != nil {
+		return err
+	}
+
+	hp.servers = resolved
+	hp.curr = -1
+	hp.last = -1
+
+	return nil
+}
+
+// Len returns the number of servers available.
+func (hp *hostProvider) Len() int {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	return len(hp.servers)
+}
+
+// Next returns the next server to connect to.
+// retryStart will be true if we've looped through all known servers without Connected() being called:
+// when that happens, servers are re-resolved.
+func (hp *hostProvider) Next() (string, bool) {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	hp.curr = (hp.curr + 1) % len(hp.servers)
+	retryStart := hp.curr == hp.last
+	if hp.last == -1 {
+		hp.last = 0
+	}
+
+	if retryStart {
+		resolved, err := hp.lookup()
+		if err != nil {
+			// Keep using the last known servers
+			fmt.Printf("[WARN] Unable to re-resolve ZooKeeper servers: %v\n", err)
+		} else {
+			hp.servers = resolved
+			hp.curr = 0
+			hp.last = 0
+		}
+	}
+
+	return hp.servers[hp.curr], retryStart
+}
+
+// Connected notifies the HostProvider of a successful connection.
+func (hp *hostProvider) Connected() {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	hp.last = hp.curr
+}
+
+// lookup (re-)discovers and resolves the servers, returning them in random order (unless ordered is set).
+func (hp *hostProvider) lookup() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+
+	if hp.srvName != "" {
+		hosts, err := lookupSRVServers(ctx, hp.srvName)
+		if err != nil {
+			// Keep using the last known hosts
+			fmt.Printf("[WARN] Unable to re-discover ZooKeeper servers: %v\n", err)
+		} else {
+			hp.hosts = hosts
+		}
+	}
+
+	servers := hp.hosts
+	if hp.resolve {
+		servers = make([]string, 0, len(hp.hosts))
+		for _, server := range hp.hosts {
+			host, port, err := net.SplitHostPort(server)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ZooKeeper server '%s': %w", server, err)
+			}
+
+			// NOTE: A single server failing to resolve (ex. while being replaced)
+			// should not prevent connecting to the others.
+			addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+			if err != nil {
+				fmt.Printf("[WARN] Unable to resolve ZooKeeper server '%s': %v\n", server, err)
+				continue
+			}
+			for _, addr := range addrs {
+				servers = append(servers, net.JoinHostPort(addr, port))
+			}
+		}
+	}
+
+	if len(servers) == 0 {
+		return nil, NewNoServersResolvedError(hp.hosts)
+	}
+
+	if hp.ordered {
+		return servers, nil
+	}
+
+	// Randomize the order of the servers to avoid creating hotspots
+	shuffled := append([]string{}, servers...)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return shuffled, nil
+}
@@ -1,7 +1,6 @@
 package client
 
 import (
-	"fmt"
 	"sync"
 )
 
@@ -9,44 +8,30 @@ import (
 // Each client is associated to a unique set of construction parameters.
 type Pool struct {
 	mu   sync.Mutex
-	pool map[string]*Client
+	pool map[Config]*Client
 }
 
 // NewPool creates a new Pool.
 func NewPool() *Pool {
 	return &Pool{
-		pool: make(map[string]*Client),
+		pool: make(map[Config]*Client),
 	}
 }
 
 // GetOrCreateClient retrieves (or creates) a Client.
-// A new client is created for each unique set of construction parameters.
-func (p *Pool) GetOrCreateClient(
-	servers string,
-	sessionTimeoutSec int,
-	username string,
-	password string,
-	tlsConfig *TLSConfig,
-) (*Client, error) {
+// A new client is created for each unique *Config.
+func (p *Pool) GetOrCreateClient(cfg *Config) (*Client, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	clientKey := fmt.Sprintf("%s::%d::%s::%s", servers, sessionTimeoutSec, username, password)
-
-	// Return client if already present for the same key
-	if client, found := p.pool[clientKey]; found {
+	// Return client if already present for the same configuration
+	if client, found := p.pool[*cfg]; found {
 		return client, nil
 	}
 
-	// Create new client, and cache it for the given key
-	client, err := NewClient(
-		servers,
-		sessionTimeoutSec,
-		username,
-		password,
-		tlsConfig,
-	)
-	p.pool[clientKey] = client
+	// Create new client, and cache it for the given configuration
+	client, err := NewClient(cfg)
+	p.pool[*cfg] = client
 
 	return client, err
 }
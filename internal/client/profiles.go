@@ -0,0 +1,172 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// DefaultProfilesFile is the path, relative to the user home directory, of the connection profiles file
+// used when EnvZooKeeperProfilesFile is not set.
+const DefaultProfilesFile = ".zookeeper/profiles.toml"
+
+// profile is a named entry of the connection profiles file.
+//
+// Keys mirror the names of the provider attributes.
+//
+//nolint:tagliatelle
+type profile struct {
+	Servers        string     `toml:"servers"`
+	ServersSRV     string     `toml:"servers_srv"`
+	SessionTimeout int        `toml:"session_timeout"`
+	Username       string     `toml:"username"`
+	Password       string     `toml:"password"`
+	Chroot         string     `toml:"chroot"`
+	ProxyURL       string     `toml:"proxy_url"`
+	TLS            profileTLS `toml:"tls"`
+	AdminServerURL string     `toml:"admin_server_url"`
+
+	SuperuserUsername string `toml:"superuser_username"`
+	SuperuserPassword string `toml:"superuser_password"`
+
+	MaxBufferSize        int    `toml:"max_buffer_size"`
+	ConnectTimeout       int    `toml:"connect_timeout"`
+	MaxReconnectAttempts int    `toml:"max_reconnect_attempts"`
+	ServerOrder          string `toml:"server_order"`
+
+	SyncReads     bool `toml:"sync_reads"`
+	AllowReadOnly bool `toml:"allow_read_only"`
+
+	WaitForPropagation bool `toml:"wait_for_propagation"`
+	PropagationTimeout int  `toml:"propagation_timeout"`
+
+	HealthCheck                       bool `toml:"health_check"`
+	HealthCheckMinQuorum              int  `toml:"health_check_min_quorum"`
+	HealthCheckMaxOutstandingRequests int  `toml:"health_check_max_outstanding_requests"`
+}
+
+//nolint:tagliatelle
+type profileTLS struct {
+	Enabled            bool   `toml:"enabled"`
+	SkipVerify         bool   `toml:"skip_verify"`
+	CAFile             string `toml:"ca_file"`
+	CertFile           string `toml:"cert_file"`
+	KeyFile            string `toml:"key_file"`
+	KeyPassword        string `toml:"key_password"`
+	KeyStoreFile       string `toml:"keystore_file"`
+	KeyStorePassword   string `toml:"keystore_password"`
+	KeyStoreType       string `toml:"keystore_type"`
+	TrustStoreFile     string `toml:"truststore_file"`
+	TrustStorePassword string `toml:"truststore_password"`
+	TrustStoreType     string `toml:"truststore_type"`
+}
+
+// DefaultProfilesFilePath returns the absolute path of DefaultProfilesFile.
+func DefaultProfilesFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to locate user home directory: %w", err)
+	}
+
+	return filepath.Join(home, DefaultProfilesFile), nil
+}
+
+// LoadProfile reads the connection profiles file (in TOML format),
+// and maps the profile with the given name onto a *Config.
+//
+// Each profile is a TOML table, named after the profile. For example:
+//
+//	[prod]
+//	servers = "zk-01:2181,zk-02:2181"
+//	session_timeout = 30
+//
+//	[prod.tls]
+//	enabled = true
+//	ca_file = "/etc/zookeeper/ca.pem"
+func LoadProfile(profilesFile, name string) (*Config, error) {
+	var profiles map[string]profile
+
+	meta, err := toml.DecodeFile(profilesFile, &profiles)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse profiles file '%s': %w", profilesFile, err)
+	}
+
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return nil, NewUnknownProfileKeyError(profilesFile, undecoded[0].String())
+	}
+
+	prof, ok := profiles[name]
+	if !ok {
+		return nil, NewProfileNotFoundError(profilesFile, name)
+	}
+
+	cfg := &Config{
+		Servers:           prof.Servers,
+		ServersSRV:        prof.ServersSRV,
+		SessionTimeoutSec: prof.SessionTimeout,
+		Username:          prof.Username,
+		Password:          prof.Password,
+		Chroot:            prof.Chroot,
+		ProxyURL:          prof.ProxyURL,
+		TLS: TLSOptions{
+			IsEnabled:          prof.TLS.Enabled,
+			SkipVerify:         prof.TLS.SkipVerify,
+			CAFile:             prof.TLS.CAFile,
+			CertFile:           prof.TLS.CertFile,
+			KeyFile:            prof.TLS.KeyFile,
+			KeyPassword:        prof.TLS.KeyPassword,
+			KeyStoreFile:       prof.TLS.KeyStoreFile,
+			KeyStorePassword:   prof.TLS.KeyStorePassword,
+			KeyStoreType:       prof.TLS.KeyStoreType,
+			TrustStoreFile:     prof.TLS.TrustStoreFile,
+			TrustStorePassword: prof.TLS.TrustStorePassword,
+			TrustStoreType:     prof.TLS.TrustStoreType,
+		},
+		AdminServerURL:        prof.AdminServerURL,
+		SuperuserUsername:     prof.SuperuserUsername,
+		SuperuserPassword:     prof.SuperuserPassword,
+		MaxBufferSize:         prof.MaxBufferSize,
+		ConnectTimeoutSec:     prof.ConnectTimeout,
+		MaxReconnectAttempts:  prof.MaxReconnectAttempts,
+		ServerOrder:           prof.ServerOrder,
+		SyncReads:             prof.SyncReads,
+		AllowReadOnly:         prof.AllowReadOnly,
+		WaitForPropagation:    prof.WaitForPropagation,
+		PropagationTimeoutSec: prof.PropagationTimeout,
+		HealthCheck:           prof.HealthCheck,
+		HealthThresholds: HealthThresholds{
+			MinQuorumSize:          prof.HealthCheckMinQuorum,
+			MaxOutstandingRequests: prof.HealthCheckMaxOutstandingRequests,
+		},
+	}
+
+	// NOTE: Boolean and integer keys are explicit even when set to their zero-value (ex. `sync_reads = false`)
+	for setting, key := range profileSettingKeys() {
+		if meta.IsDefined(append([]string{name}, key...)...) {
+			cfg.ExplicitSettings |= setting
+		}
+	}
+
+	return cfg, nil
+}
+
+// profileSettingKeys returns the keys of the profile, for each boolean and integer Setting.
+func profileSettingKeys() map[Setting][]string {
+	return map[Setting][]string{
+		SettingSessionTimeout:                    {"session_timeout"},
+		SettingMaxBufferSize:                     {"max_buffer_size"},
+		SettingConnectTimeout:                    {"connect_timeout"},
+		SettingMaxReconnectAttempts:              {"max_reconnect_attempts"},
+		SettingSyncReads:                         {"sync_reads"},
+		SettingAllowReadOnly:                     {"allow_read_only"},
+		SettingWaitForPropagation:                {"wait_for_propagation"},
+		SettingPropagationTimeout:                {"propagation_timeout"},
+		SettingHealthCheck:                       {"health_check"},
+		SettingHealthCheckMinQuorum:              {"health_check_min_quorum"},
+		SettingHealthCheckMaxOutstandingRequests: {"health_check_max_outstanding_requests"},
+		SettingTLSEnabled:                        {"tls", "enabled"},
+		SettingTLSSkipVerify:                     {"tls", "skip_verify"},
+	}
+}
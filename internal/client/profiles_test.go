@@ -0,0 +1,93 @@
+package client_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	testifyRequire "github.com/stretchr/testify/require"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+const testProfiles = `
+[dev]
+servers = "localhost:2181"
+
+[prod]
+servers         = "zk-01:2182,zk-02:2182"
+session_timeout = 60
+username        = "deployer"
+password        = "secret"
+
+[prod.tls]
+enabled             = true
+truststore_file     = "/etc/zookeeper/truststore.jks"
+truststore_password = "changeit"
+`
+
+func writeProfilesFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profiles.toml")
+	testifyRequire.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadProfile(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+	profilesFile := writeProfilesFile(t, testProfiles)
+
+	cfg, err := client.LoadProfile(profilesFile, "prod")
+	require.NoError(err)
+	assert.Equal("zk-01:2182,zk-02:2182", cfg.Servers)
+	assert.Equal(60, cfg.SessionTimeoutSec)
+	assert.Equal("deployer", cfg.Username)
+	assert.Equal("secret", cfg.Password)
+	assert.True(cfg.TLS.IsEnabled)
+	assert.Equal("/etc/zookeeper/truststore.jks", cfg.TLS.TrustStoreFile)
+	assert.Equal("changeit", cfg.TLS.TrustStorePassword)
+	assert.Equal(client.SettingSessionTimeout|client.SettingTLSEnabled, cfg.ExplicitSettings)
+
+	_, err = client.LoadProfile(profilesFile, "stage")
+	require.EqualError(
+		err,
+		"profile 'stage' not found in profiles file '"+profilesFile+"'",
+	)
+
+	profilesFile = writeProfilesFile(t, "[dev]\nserver = \"localhost:2181\"\n")
+	_, err = client.LoadProfile(profilesFile, "dev")
+	require.EqualError(err, "unknown key 'dev.server' in profiles file '"+profilesFile+"'")
+}
+
+func TestResolveConfigPrecedence(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	cfg, err := client.ResolveConfig(&client.ConfigSources{
+		ClientConfigFile: writeClientConfigFile(t, `
+zookeeper.connect=zk-file:2181
+zookeeper.session.timeout.ms=15000
+zookeeper.ssl.keyStore.location=/etc/zookeeper/keystore.jks
+`),
+		ProfilesFile: writeProfilesFile(t, testProfiles),
+		Profile:      "prod",
+	}, &client.Config{
+		Username: "explicit-user",
+		Password: "explicit-pass",
+		// Explicitly disabled, despite the profile
+		ExplicitSettings: client.SettingTLSEnabled,
+	})
+	require.NoError(err)
+
+	assert.Equal("zk-01:2182,zk-02:2182", cfg.Servers)
+	assert.Equal(60, cfg.SessionTimeoutSec)
+	assert.Equal("explicit-user", cfg.Username)
+	assert.Equal("explicit-pass", cfg.Password)
+	assert.Equal("/etc/zookeeper/keystore.jks", cfg.TLS.KeyStoreFile)
+	assert.Equal("/etc/zookeeper/truststore.jks", cfg.TLS.TrustStoreFile)
+	assert.False(cfg.TLS.IsEnabled)
+
+	cfg, err = client.ResolveConfig(&client.ConfigSources{}, &client.Config{Servers: "zk:2181"})
+	require.NoError(err)
+	assert.Equal(client.DefaultZooKeeperSessionSec, cfg.SessionTimeoutSec)
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+var (
+	// ErrNotPropagated returned when a write has not reached a server yet.
+	ErrNotPropagated = errors.New("write not propagated yet")
+
+	// ErrServerNotResponding returned when a server doesn't respond before the propagation timeout
+	// (ex. it's unreachable).
+	ErrServerNotResponding = errors.New("server not responding")
+)
+
+// propagationPollInterval is how often each server is polled, while waiting for a write to reach it.
+const propagationPollInterval = 100 * time.Millisecond
+
+// propagationWaiter waits for writes to reach every member of the ensemble.
+//
+// It holds a dedicated connection to each server, as the main connection of the Client
+// is only connected to one server at a time.
+type propagationWaiter struct {
+	servers []string
+	timeout time.Duration
+	connect func(server string) (*zk.Conn, error)
+
+	mu    sync.Mutex
+	conns map[string]*zk.Conn
+}
+
+func newPropagationWaiter(
+	servers []string,
+	timeout time.Duration,
+	connect func(server string) (*zk.Conn, error),
+) *propagationWaiter {
+	return &propagationWaiter{
+		servers: servers,
+		timeout: timeout,
+		connect: connect,
+		conns:   make(map[string]*zk.Conn),
+	}
+}
+
+// waitForZxid waits until the ZNode at the given (absolute) path has, on every server, an `mzxid`
+// that is at least the given zxid (i.e. the zxid of the write).
+func (w *propagationWaiter) waitForZxid(path string, zxid int64) error {
+	return w.waitFor(path, func(exists bool, stat *zk.Stat) bool {
+		return exists && stat.Mzxid >= zxid
+	})
+}
+
+// waitForDeletion waits until the ZNode at the given (absolute) path doesn't exist on any server.
+func (w *propagationWaiter) waitForDeletion(path string) error {
+	return w.waitFor(path, func(exists bool, _ *zk.Stat) bool {
+		return !exists
+	})
+}
+
+func (w *propagationWaiter) waitFor(path string, propagated func(bool, *zk.Stat) bool) error {
+	deadline := time.Now().Add(w.timeout)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		lagging []string
+	)
+	for _, server := range w.servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := w.waitForServer(server, path, deadline, propagated); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				lagging = append(lagging, fmt.Sprintf("%s (%v)", server, err))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(lagging) > 0 {
+		sort.Strings(lagging)
+		return NewPropagationTimeoutError(path, w.timeout, lagging)
+	}
+
+	return nil
+}
+
+func (w *propagationWaiter) waitForServer(
+	server, path string,
+	deadline time.Time,
+	propagated func(bool, *zk.Stat) bool,
+) error {
+	conn, err := w.conn(server)
+	if err != nil {
+		return err
+	}
+
+	for {
+		exists, stat, err := existsBefore(conn, path, deadline)
+		if err == nil && propagated(exists, stat) {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("failed to check ZNode: %w", err)
+			}
+			return ErrNotPropagated
+		}
+		time.Sleep(propagationPollInterval)
+	}
+}
+
+// existsResult is the outcome of zk.Conn.Exists.
+type existsResult struct {
+	exists bool
+	stat   *zk.Stat
+	err    error
+}
+
+// existsBefore checks if the ZNode at the given (absolute) path exists, giving up at the given deadline.
+//
+// NOTE: Requests block while the connection is down (ex. the server is unreachable),
+// so they are bounded by the deadline, rather than by the session timeout.
+func existsBefore(conn *zk.Conn, path string, deadline time.Time) (bool, *zk.Stat, error) {
+	result := make(chan existsResult, 1)
+	go func() {
+		exists, stat, err := conn.Exists(path)
+		result <- existsResult{exists, stat, err}
+	}()
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	select {
+	case res := <-result:
+		return res.exists, res.stat, res.err
+	case <-timer.C:
+		return false, nil, ErrServerNotResponding
+	}
+}
+
+// conn returns the dedicated connection to the given server, creating it if necessary.
+func (w *propagationWaiter) conn(server string) (*zk.Conn, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if conn, found := w.conns[server]; found {
+		return conn, nil
+	}
+
+	conn, err := w.connect(server)
+	if err != nil {
+		return nil, err
+	}
+	w.conns[server] = conn
+
+	return conn, nil
+}
+
+// close all the dedicated connections.
+func (w *propagationWaiter) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for server, conn := range w.conns {
+		conn.Close()
+		delete(w.conns, server)
+	}
+}
@@ -0,0 +1,254 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Keys of the ZooKeeper Java client configuration file (i.e. `zookeeper-client.properties`)
+// that are understood by LoadClientConfigFile.
+//
+// See: https://zookeeper.apache.org/doc/current/zookeeperAdmin.html#sc_java_clientConfig.
+const (
+	// PropConnect is the connection string. This is not part of the ZooKeeper Java client
+	// configuration, but it's the key used by most tools built on top of it (ex. Kafka).
+	PropConnect = "zookeeper.connect"
+	// PropSessionTimeoutMs is the session timeout, in milliseconds (ex. Kafka).
+	PropSessionTimeoutMs = "zookeeper.session.timeout.ms"
+	// PropAuthDigest provides digest auth credentials, in the `username:password` format
+	// used by the `addauth digest` command of the ZooKeeper CLI.
+	//
+	// NOTE: This is specific to this provider: the ZooKeeper Java client (and the tools built on top of it)
+	// ignore it, as they get digest credentials via `addAuthInfo` or JAAS.
+	PropAuthDigest = "zookeeper.auth.digest"
+	// PropConnectionTimeoutMs is the connection timeout, in milliseconds (ex. Kafka).
+	PropConnectionTimeoutMs = "zookeeper.connection.timeout.ms"
+
+	PropJuteMaxBuffer = "jute.maxbuffer"
+
+	PropClientSecure       = "zookeeper.client.secure"
+	PropClientCnxnSocket   = "zookeeper.clientCnxnSocket"
+	PropKeyStoreLocation   = "zookeeper.ssl.keyStore.location"
+	PropKeyStorePassword   = "zookeeper.ssl.keyStore.password"
+	PropKeyStoreType       = "zookeeper.ssl.keyStore.type"
+	PropTrustStoreLocation = "zookeeper.ssl.trustStore.location"
+	PropTrustStorePassword = "zookeeper.ssl.trustStore.password"
+	PropTrustStoreType     = "zookeeper.ssl.trustStore.type"
+)
+
+const millisPerSecond = 1000
+
+// LoadClientConfigFile reads a ZooKeeper Java client configuration file,
+// and maps its content onto a *Config.
+//
+// The file is expected to follow the Java `.properties` format.
+// Keys that don't have an equivalent in this client
+// (ex. `zookeeper.clientCnxnSocket`) are ignored.
+func LoadClientConfigFile(path string) (*Config, error) {
+	content, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("unable to read client config file: %w", err)
+	}
+
+	props, err := parseProperties(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse client config file '%s': %w", path, err)
+	}
+
+	cfg := &Config{
+		Servers: props[PropConnect],
+		TLS: TLSOptions{
+			KeyStoreFile:       props[PropKeyStoreLocation],
+			KeyStorePassword:   props[PropKeyStorePassword],
+			KeyStoreType:       props[PropKeyStoreType],
+			TrustStoreFile:     props[PropTrustStoreLocation],
+			TrustStorePassword: props[PropTrustStorePassword],
+			TrustStoreType:     props[PropTrustStoreType],
+		},
+	}
+
+	if secure, ok := props[PropClientSecure]; ok {
+		cfg.TLS.IsEnabled, err = strconv.ParseBool(secure)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' in '%s': %w", PropClientSecure, path, err)
+		}
+		cfg.ExplicitSettings |= SettingTLSEnabled
+	}
+
+	for prop, intProp := range map[string]struct {
+		dst     *int
+		setting Setting
+	}{
+		PropSessionTimeoutMs:    {&cfg.SessionTimeoutSec, SettingSessionTimeout},
+		PropConnectionTimeoutMs: {&cfg.ConnectTimeoutSec, SettingConnectTimeout},
+		PropJuteMaxBuffer:       {&cfg.MaxBufferSize, SettingMaxBufferSize},
+	} {
+		if value, ok := props[prop]; ok {
+			valueInt, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid '%s' in '%s': %w", prop, path, err)
+			}
+			*intProp.dst = valueInt
+			cfg.ExplicitSettings |= intProp.setting
+		}
+	}
+
+	// Timeouts are expressed in milliseconds
+	cfg.SessionTimeoutSec = millisToSeconds(cfg.SessionTimeoutSec)
+	cfg.ConnectTimeoutSec = millisToSeconds(cfg.ConnectTimeoutSec)
+
+	if digest, ok := props[PropAuthDigest]; ok {
+		username, password, found := strings.Cut(digest, ":")
+		if !found {
+			return nil, fmt.Errorf(
+				"invalid '%s' in '%s': %w",
+				PropAuthDigest,
+				path,
+				ErrUserPassBothOrNone,
+			)
+		}
+		cfg.Username, cfg.Password = username, password
+	}
+
+	if socket, ok := props[PropClientCnxnSocket]; ok {
+		fmt.Printf("[DEBUG] Ignoring '%s=%s': not applicable\n", PropClientCnxnSocket, socket)
+	}
+
+	return cfg, nil
+}
+
+// millisToSeconds converts the given milliseconds to seconds, rounding up:
+// this way, timeouts below a second are not truncated to `0` (i.e. the default).
+func millisToSeconds(millis int) int {
+	if millis <= 0 {
+		return millis
+	}
+
+	return (millis + millisPerSecond - 1) / millisPerSecond
+}
+
+// parseProperties parses the content of a Java `.properties` file.
+//
+// It supports the full format described in `java.util.Properties#load`:
+// `#` and `!` comments, `=`, `:` or whitespace separators,
+// line continuations and escape sequences (including `\uXXXX`).
+func parseProperties(r io.Reader) (map[string]string, error) {
+	props := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	logicalLine := ""
+	for scanner.Scan() {
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+		if logicalLine == "" && (line == "" || line[0] == '#' || line[0] == '!') {
+			continue
+		}
+
+		// A line ending with an odd number of backslashes continues on the next line
+		trailing := len(line) - len(strings.TrimRight(line, `\`))
+		if trailing%2 == 1 {
+			logicalLine += line[:len(line)-1]
+			continue
+		}
+		logicalLine += line
+
+		key, value, err := splitProperty(logicalLine)
+		if err != nil {
+			return nil, err
+		}
+		props[key] = value
+		logicalLine = ""
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed reading properties: %w", err)
+	}
+
+	if logicalLine != "" {
+		key, value, err := splitProperty(logicalLine)
+		if err != nil {
+			return nil, err
+		}
+		props[key] = value
+	}
+
+	return props, nil
+}
+
+// splitProperty splits a logical line into its (unescaped) key and value.
+func splitProperty(line string) (string, string, error) {
+	keyEnd := len(line)
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.IndexByte("=: \t\f", line[i]) >= 0 {
+			keyEnd = i
+			break
+		}
+	}
+
+	rest := strings.TrimLeft(line[keyEnd:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	key, err := unescapeProperty(line[:keyEnd])
+	if err != nil {
+		return "", "", err
+	}
+
+	value, err := unescapeProperty(rest)
+	if err != nil {
+		return "", "", err
+	}
+
+	return key, value, nil
+}
+
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if len(s)-(i+1) < 4 {
+				return "", NewMalformedPropertyEscapeError(s)
+			}
+			codePoint, err := strconv.ParseUint(s[i+1:i+5], 16, 32)
+			if err != nil {
+				return "", NewMalformedPropertyEscapeError(s)
+			}
+			sb.WriteRune(rune(codePoint))
+			i += 4
+		default:
+			r, size := utf8.DecodeRuneInString(s[i:])
+			sb.WriteRune(r)
+			i += size - 1
+		}
+	}
+
+	return sb.String(), nil
+}
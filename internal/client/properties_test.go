@@ -0,0 +1,120 @@
+package client_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	testifyRequire "github.com/stretchr/testify/require"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func writeClientConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "zookeeper-client.properties")
+	testifyRequire.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadClientConfigFile(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	cfg, err := client.LoadClientConfigFile(writeClientConfigFile(t, `
+# Connection
+zookeeper.connect = zk-01:2281,\
+                    zk-02:2281
+zookeeper.session.timeout.ms: 18000
+zookeeper.connection.timeout.ms=5000
+jute.maxbuffer=4194304
+zookeeper.auth.digest=user:pass:word
+
+! TLS
+zookeeper.clientCnxnSocket=org.apache.zookeeper.ClientCnxnSocketNetty
+zookeeper.client.secure=true
+zookeeper.ssl.keyStore.location /etc/zookeeper/keystore.jks
+zookeeper.ssl.keyStore.password=key\=store
+zookeeper.ssl.trustStore.location=/etc/zookeeper/truststore.p12
+zookeeper.ssl.trustStore.type=PKCS12
+`))
+	require.NoError(err)
+
+	assert.Equal("zk-01:2281,zk-02:2281", cfg.Servers)
+	assert.Equal(18, cfg.SessionTimeoutSec)
+	assert.Equal(5, cfg.ConnectTimeoutSec)
+	assert.Equal(4194304, cfg.MaxBufferSize)
+	assert.Equal("user", cfg.Username)
+	assert.Equal("pass:word", cfg.Password)
+	assert.True(cfg.TLS.IsEnabled)
+	assert.Equal("/etc/zookeeper/keystore.jks", cfg.TLS.KeyStoreFile)
+	assert.Equal("key=store", cfg.TLS.KeyStorePassword)
+	assert.Empty(cfg.TLS.KeyStoreType)
+	assert.Equal("/etc/zookeeper/truststore.p12", cfg.TLS.TrustStoreFile)
+	assert.Equal("PKCS12", cfg.TLS.TrustStoreType)
+	assert.Equal(
+		client.SettingSessionTimeout|client.SettingConnectTimeout|
+			client.SettingMaxBufferSize|client.SettingTLSEnabled,
+		cfg.ExplicitSettings,
+	)
+}
+
+func TestLoadClientConfigFileTimeoutsRoundUp(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	cfg, err := client.LoadClientConfigFile(writeClientConfigFile(t, `
+zookeeper.session.timeout.ms=15500
+zookeeper.connection.timeout.ms=500
+`))
+	require.NoError(err)
+
+	assert.Equal(16, cfg.SessionTimeoutSec)
+	assert.Equal(1, cfg.ConnectTimeoutSec)
+}
+
+func TestLoadClientConfigFileErrors(t *testing.T) {
+	require := testifyRequire.New(t)
+
+	_, err := client.LoadClientConfigFile(writeClientConfigFile(t, "zookeeper.client.secure=maybe"))
+	require.ErrorContains(err, "invalid 'zookeeper.client.secure'")
+
+	_, err = client.LoadClientConfigFile(writeClientConfigFile(t, "zookeeper.auth.digest=user"))
+	require.ErrorIs(err, client.ErrUserPassBothOrNone)
+
+	_, err = client.LoadClientConfigFile(writeClientConfigFile(t, `zookeeper.connect=\u12`))
+	require.ErrorContains(err, `malformed \uXXXX escape sequence`)
+
+	_, err = client.LoadClientConfigFile(filepath.Join(t.TempDir(), "does-not-exist"))
+	require.Error(err)
+}
+
+func TestConfigMerge(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	cfg := &client.Config{
+		Servers:           "zk-file:2181",
+		SessionTimeoutSec: 10,
+		TLS:               client.TLSOptions{IsEnabled: true, TrustStoreFile: "/truststore.jks"},
+	}
+	cfg.Merge(&client.Config{
+		Servers:  "zk-explicit:2181",
+		Username: "user",
+		Password: "pass",
+	})
+
+	assert.Equal("zk-explicit:2181", cfg.Servers)
+	assert.Equal(10, cfg.SessionTimeoutSec)
+	assert.Equal("user", cfg.Username)
+	assert.True(cfg.TLS.IsEnabled)
+	assert.Equal("/truststore.jks", cfg.TLS.TrustStoreFile)
+
+	// Explicit zero-values are merged too
+	cfg.Merge(&client.Config{
+		SessionTimeoutSec: 0,
+		TLS:               client.TLSOptions{IsEnabled: false},
+		ExplicitSettings:  client.SettingTLSEnabled,
+	})
+
+	assert.Equal(10, cfg.SessionTimeoutSec)
+	assert.False(cfg.TLS.IsEnabled)
+}
@@ -0,0 +1,325 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-zookeeper/zk"
+)
+
+const (
+	// QuotaRootPath is the ZNode under which ZooKeeper stores the quotas:
+	// the quota of a ZNode at `<path>` is stored under `QuotaRootPath<path>`.
+	QuotaRootPath = "/zookeeper/quota"
+
+	// QuotaUnlimited is the value of a quota limit that is not set.
+	QuotaUnlimited int64 = -1
+
+	quotaLimitsNode = "zookeeper_limits"
+	quotaStatsNode  = "zookeeper_stats"
+
+	// Keys of the `count=...,bytes=...` format used by ZooKeeper for both limits and stats.
+	quotaKeyCount          = "count"
+	quotaKeyBytes          = "bytes"
+	quotaKeyCountHardLimit = "countHardLimit"
+	quotaKeyByteHardLimit  = "byteHardLimit"
+)
+
+var (
+	// ErrQuotaNotFound returned when the ZNode has no quota set.
+	ErrQuotaNotFound = errors.New("quota not found")
+
+	// ErrInvalidQuotaPath returned when attempting to set a quota on the root ZNode,
+	// or on the ZNodes reserved to ZooKeeper (i.e. under `/zookeeper`).
+	ErrInvalidQuotaPath = errors.New("quota cannot be set on the root or on '/zookeeper'")
+)
+
+// QuotaLimits are the limits of a quota, on the number of ZNodes (including the ZNode itself)
+// and on the bytes of data of the subtree. Each limit is QuotaUnlimited, unless set.
+//
+// Soft limits only make ZooKeeper log a warning when exceeded, while hard limits make it refuse the write
+// (ZooKeeper 3.7 or later, with `zookeeper.enforceQuota=true`).
+type QuotaLimits struct {
+	Count          int64
+	Bytes          int64
+	CountHardLimit int64
+	BytesHardLimit int64
+}
+
+// Quota represents the quota of a ZNode, with the current usage of its subtree.
+type Quota struct {
+	Path   string
+	Limits QuotaLimits
+	// Count and Bytes are the current usage, as tracked by ZooKeeper.
+	Count int64
+	Bytes int64
+}
+
+// String returns the limits in the format stored by ZooKeeper (ex. `count=10,bytes=-1`).
+//
+// Hard limits are included only if set, as ZooKeeper versions older than 3.7 don't understand them.
+func (l *QuotaLimits) String() string {
+	formatted := fmt.Sprintf("%s=%d,%s=%d", quotaKeyCount, l.Count, quotaKeyBytes, l.Bytes)
+	if l.CountHardLimit != QuotaUnlimited || l.BytesHardLimit != QuotaUnlimited {
+		formatted += fmt.Sprintf(
+			",%s=%d,%s=%d",
+			quotaKeyCountHardLimit,
+			l.CountHardLimit,
+			quotaKeyByteHardLimit,
+			l.BytesHardLimit,
+		)
+	}
+
+	return formatted
+}
+
+// ParseQuotaLimits parses the limits in the format stored by ZooKeeper (ex. `count=10,bytes=-1`).
+//
+// Limits missing from the input are QuotaUnlimited.
+func ParseQuotaLimits(raw string) (*QuotaLimits, error) {
+	values, err := parseQuotaValues(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuotaLimits{
+		Count:          values[quotaKeyCount],
+		Bytes:          values[quotaKeyBytes],
+		CountHardLimit: values[quotaKeyCountHardLimit],
+		BytesHardLimit: values[quotaKeyByteHardLimit],
+	}, nil
+}
+
+// parseQuotaValues parses the `key=value,...` format used by ZooKeeper for quotas.
+// All the known keys are returned, defaulting to QuotaUnlimited; unknown keys are ignored.
+func parseQuotaValues(raw string) (map[string]int64, error) {
+	values := map[string]int64{
+		quotaKeyCount:          QuotaUnlimited,
+		quotaKeyBytes:          QuotaUnlimited,
+		quotaKeyCountHardLimit: QuotaUnlimited,
+		quotaKeyByteHardLimit:  QuotaUnlimited,
+	}
+
+	for _, pair := range strings.Split(strings.TrimSpace(raw), ",") {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, NewInvalidQuotaError(raw, "expected 'key=value' pairs")
+		}
+
+		if _, known := values[key]; !known {
+			continue
+		}
+
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, NewInvalidQuotaError(raw, fmt.Sprintf("'%s' is not an integer", key))
+		}
+		values[key] = parsed
+	}
+
+	return values, nil
+}
+
+// quotaPath returns the absolute path under which the quota of the given ZNode is stored.
+func (c *Client) quotaPath(path string) (string, error) {
+	absPath := c.absPath(path)
+	if absPath == zNodeRootPath || absPath == "/zookeeper" ||
+		strings.HasPrefix(absPath, "/zookeeper/") {
+		return "", fmt.Errorf("invalid quota path '%s': %w", path, ErrInvalidQuotaPath)
+	}
+
+	return QuotaRootPath + absPath, nil
+}
+
+// ReadQuota reads the quota of the ZNode at the given path, with the current usage of its subtree.
+//
+// Returns ErrQuotaNotFound if the ZNode has no quota set.
+func (c *Client) ReadQuota(path string) (*Quota, error) {
+	quotaPath, err := c.quotaPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.syncReads && !c.IsReadOnly() {
+		if _, err := c.zkConn.Sync(quotaPath); err != nil && !errors.Is(err, zk.ErrNoNode) {
+			return nil, fmt.Errorf("failed to sync quota of ZNode '%s': %w", path, err)
+		}
+	}
+
+	// NOTE: Quotas are not subject to the chroot
+	rawLimits, _, err := c.zkConn.Get(quotaPath + "/" + quotaLimitsNode)
+	if errors.Is(err, zk.ErrNoNode) {
+		return nil, fmt.Errorf("failed to read quota of ZNode '%s': %w", path, ErrQuotaNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quota of ZNode '%s': %w", path, err)
+	}
+
+	limits, err := ParseQuotaLimits(string(rawLimits))
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &Quota{
+		Path:   path,
+		Limits: *limits,
+		Count:  QuotaUnlimited,
+		Bytes:  QuotaUnlimited,
+	}
+
+	rawStats, _, err := c.zkConn.Get(quotaPath + "/" + quotaStatsNode)
+	if err != nil && !errors.Is(err, zk.ErrNoNode) {
+		return nil, fmt.Errorf("failed to read quota usage of ZNode '%s': %w", path, err)
+	}
+	if err == nil {
+		stats, err := parseQuotaValues(string(rawStats))
+		if err != nil {
+			return nil, err
+		}
+		quota.Count, quota.Bytes = stats[quotaKeyCount], stats[quotaKeyBytes]
+	}
+
+	return quota, nil
+}
+
+// SetQuota sets (or replaces) the quota of the ZNode at the given path, that must exist.
+//
+// Like the `setquota` command of the ZooKeeper CLI, it refuses to nest quotas
+// (i.e. to set a quota on a ZNode with an ancestor or a descendant that has a quota set):
+// ZooKeeper would only track the usage of one of them.
+func (c *Client) SetQuota(path string, limits *QuotaLimits) (*Quota, error) {
+	if c.IsReadOnly() {
+		return nil, fmt.Errorf("failed to set quota of ZNode '%s': %w", path, ErrReadOnlyMode)
+	}
+
+	quotaPath, err := c.quotaPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := c.Exists(path)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("failed to set quota of ZNode '%s': %w", path, ErrZNodeDoesNotExist)
+	}
+
+	if err := c.checkNoNestedQuota(path, quotaPath); err != nil {
+		return nil, err
+	}
+
+	acl := zk.WorldACL(zk.PermAll)
+	if _, err := c.createEmptyZNodes(append(listParentsInOrder(quotaPath), quotaPath), 0, acl); err != nil {
+		return nil, err
+	}
+
+	limitsPath := quotaPath + "/" + quotaLimitsNode
+	_, err = c.zkConn.Create(limitsPath, []byte(limits.String()), 0, acl)
+	if errors.Is(err, zk.ErrNodeExists) {
+		_, err = c.zkConn.Set(limitsPath, []byte(limits.String()), matchAnyVersion)
+	}
+	if err = translateReadOnlyError(err); err != nil {
+		return nil, fmt.Errorf("failed to set quota of ZNode '%s': %w", path, err)
+	}
+
+	// NOTE: ZooKeeper computes the usage of the subtree when the stats ZNode is created,
+	// replacing whatever data it's created with
+	statsPath := quotaPath + "/" + quotaStatsNode
+	initialStats := fmt.Sprintf("%s=0,%s=0", quotaKeyCount, quotaKeyBytes)
+	_, err = c.zkConn.Create(statsPath, []byte(initialStats), 0, acl)
+	if err = translateReadOnlyError(err); err != nil && !errors.Is(err, zk.ErrNodeExists) {
+		return nil, fmt.Errorf("failed to set quota of ZNode '%s': %w", path, err)
+	}
+
+	return c.ReadQuota(path)
+}
+
+// checkNoNestedQuota checks that no ancestor or descendant of the ZNode at the given path has a quota set.
+func (c *Client) checkNoNestedQuota(path, quotaPath string) error {
+	for _, parent := range listParentsInOrder(quotaPath) {
+		if len(parent) <= len(QuotaRootPath) {
+			continue
+		}
+
+		exists, _, err := c.zkConn.Exists(parent + "/" + quotaLimitsNode)
+		if err != nil {
+			return fmt.Errorf("failed to check quota of ZNode '%s': %w", parent, err)
+		}
+		if exists {
+			return NewNestedQuotaError(path, strings.TrimPrefix(parent, QuotaRootPath))
+		}
+	}
+
+	return c.checkNoDescendantQuota(path, quotaPath)
+}
+
+func (c *Client) checkNoDescendantQuota(path, quotaPath string) error {
+	children, _, err := c.zkConn.Children(quotaPath)
+	if errors.Is(err, zk.ErrNoNode) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list quotas under ZNode '%s': %w", path, err)
+	}
+
+	for _, child := range children {
+		if child == quotaLimitsNode || child == quotaStatsNode {
+			continue
+		}
+
+		childQuotaPath := quotaPath + "/" + child
+		exists, _, err := c.zkConn.Exists(childQuotaPath + "/" + quotaLimitsNode)
+		if err != nil {
+			return fmt.Errorf("failed to check quota of ZNode '%s': %w", childQuotaPath, err)
+		}
+		if exists {
+			return NewNestedQuotaError(path, strings.TrimPrefix(childQuotaPath, QuotaRootPath))
+		}
+
+		if err := c.checkNoDescendantQuota(path, childQuotaPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteQuota removes the quota of the ZNode at the given path.
+//
+// The ZNodes that stored the quota are removed as well, up to QuotaRootPath, unless they
+// store other quotas (i.e. they have other children).
+func (c *Client) DeleteQuota(path string) error {
+	if c.IsReadOnly() {
+		return fmt.Errorf("failed to delete quota of ZNode '%s': %w", path, ErrReadOnlyMode)
+	}
+
+	quotaPath, err := c.quotaPath(path)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range []string{quotaLimitsNode, quotaStatsNode} {
+		err := translateReadOnlyError(c.zkConn.Delete(quotaPath+"/"+node, matchAnyVersion))
+		if err != nil && !errors.Is(err, zk.ErrNoNode) {
+			return fmt.Errorf("failed to delete quota of ZNode '%s': %w", path, err)
+		}
+	}
+
+	// Remove the (now) empty ZNodes that stored the quota, deepest first
+	emptyPaths := listParentsInOrder(quotaPath)
+	emptyPaths = append(emptyPaths[len(listParentsInOrder(QuotaRootPath))+1:], quotaPath)
+	for i := len(emptyPaths) - 1; i >= 0; i-- {
+		err := translateReadOnlyError(c.zkConn.Delete(emptyPaths[i], matchAnyVersion))
+		if errors.Is(err, zk.ErrNotEmpty) {
+			break
+		}
+		if err != nil && !errors.Is(err, zk.ErrNoNode) {
+			return fmt.Errorf("failed to delete quota ZNode '%s': %w", emptyPaths[i], err)
+		}
+	}
+
+	return nil
+}
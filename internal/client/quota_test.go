@@ -0,0 +1,82 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/go-zookeeper/zk"
+	testifyAssert "github.com/stretchr/testify/assert"
+	testifyRequire "github.com/stretchr/testify/require"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func TestParseQuotaLimits(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	limits, err := client.ParseQuotaLimits("count=10,bytes=-1")
+	require.NoError(err)
+	assert.Equal(&client.QuotaLimits{
+		Count:          10,
+		Bytes:          client.QuotaUnlimited,
+		CountHardLimit: client.QuotaUnlimited,
+		BytesHardLimit: client.QuotaUnlimited,
+	}, limits)
+	assert.Equal("count=10,bytes=-1", limits.String())
+
+	limits, err = client.ParseQuotaLimits("count=-1,bytes=-1,countHardLimit=5,byteHardLimit=1024")
+	require.NoError(err)
+	assert.Equal(int64(5), limits.CountHardLimit)
+	assert.Equal(int64(1024), limits.BytesHardLimit)
+	assert.Equal("count=-1,bytes=-1,countHardLimit=5,byteHardLimit=1024", limits.String())
+
+	_, err = client.ParseQuotaLimits("count=ten,bytes=-1")
+	require.ErrorContains(err, "'count' is not an integer")
+
+	_, err = client.ParseQuotaLimits("count")
+	require.ErrorContains(err, "expected 'key=value' pairs")
+}
+
+func TestQuota(t *testing.T) {
+	zkClient, assert, require := initTest(t)
+	defer zkClient.Close()
+
+	_, err := zkClient.Create("/test/Quota/child", []byte("data"), zk.WorldACL(zk.PermAll))
+	require.NoError(err)
+	defer func() {
+		require.NoError(zkClient.Delete("/test"))
+	}()
+
+	_, err = zkClient.ReadQuota("/test/Quota")
+	require.ErrorIs(err, client.ErrQuotaNotFound)
+
+	quota, err := zkClient.SetQuota("/test/Quota", &client.QuotaLimits{
+		Count:          10,
+		Bytes:          client.QuotaUnlimited,
+		CountHardLimit: client.QuotaUnlimited,
+		BytesHardLimit: 1024,
+	})
+	require.NoError(err)
+	assert.Equal(int64(10), quota.Limits.Count)
+	assert.Equal(int64(1024), quota.Limits.BytesHardLimit)
+	assert.Equal(int64(2), quota.Count)
+	assert.Equal(int64(4), quota.Bytes)
+
+	_, err = zkClient.SetQuota("/test/Quota/child", &client.QuotaLimits{
+		Count:          1,
+		Bytes:          client.QuotaUnlimited,
+		CountHardLimit: client.QuotaUnlimited,
+		BytesHardLimit: client.QuotaUnlimited,
+	})
+	var nestedErr *client.NestedQuotaError
+	require.ErrorAs(err, &nestedErr)
+
+	_, err = zkClient.SetQuota("/zookeeper", &client.QuotaLimits{})
+	require.ErrorIs(err, client.ErrInvalidQuotaPath)
+
+	require.NoError(zkClient.DeleteQuota("/test/Quota"))
+	_, err = zkClient.ReadQuota("/test/Quota")
+	require.ErrorIs(err, client.ErrQuotaNotFound)
+
+	quotaExists, err := zkClient.Exists("/zookeeper/quota/test")
+	require.NoError(err)
+	assert.False(quotaExists)
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync/atomic"
+)
+
+// ErrReadOnlyMode returned when attempting a write, while connected to a ZooKeeper server in read-only mode.
+var ErrReadOnlyMode = errors.New(
+	"ZooKeeper server is in read-only mode (i.e. the ensemble has lost quorum): writes are not allowed",
+)
+
+const (
+	// errCodeNotReadOnly is the error code returned by a read-only ZooKeeper server, when attempting a write.
+	// NOTE: It's not known to the go-zookeeper library, so it surfaces as an "unknown error".
+	errCodeNotReadOnly = -119
+
+	// connectResponseFixedLen is the length of the fixed-size fields of the connect response
+	// (i.e. protocolVersion, timeOut and sessionId), that precede the password.
+	connectResponseFixedLen = 4 + 4 + 8
+
+	// frameLengthLen is the length of the prefix carrying the length of each frame of the ZooKeeper protocol.
+	frameLengthLen = 4
+)
+
+// readOnlyConn wraps a net.Conn to a ZooKeeper server, to allow connecting to it while in read-only mode.
+//
+// The go-zookeeper library doesn't support the `canBeReadOnly` flag of the connect request, so this appends it
+// to the first frame written (i.e. the connect request), and detects the `readOnly` flag at the end of the first
+// frame read (i.e. the connect response).
+type readOnlyConn struct {
+	net.Conn
+
+	// readOnly reports if the server is in read-only mode, once the connect response has been received.
+	readOnly *atomic.Bool
+
+	requestWritten bool
+	responseRead   bool
+	pending        []byte // Connect response, waiting to be read
+}
+
+func newReadOnlyConn(conn net.Conn, readOnly *atomic.Bool) *readOnlyConn {
+	return &readOnlyConn{
+		Conn:     conn,
+		readOnly: readOnly,
+	}
+}
+
+// Write the given bytes, appending `canBeReadOnly = true` to the connect request.
+func (c *readOnlyConn) Write(p []byte) (int, error) {
+	if c.requestWritten {
+		return c.Conn.Write(p) //nolint:wrapcheck
+	}
+	c.requestWritten = true
+
+	// Pass-through anything that doesn't look like a single frame
+	if len(p) < frameLengthLen || int(binary.BigEndian.Uint32(p)) != len(p)-frameLengthLen {
+		return c.Conn.Write(p) //nolint:wrapcheck
+	}
+
+	request := make([]byte, len(p)+1)
+	binary.BigEndian.PutUint32(request, uint32(len(p)-frameLengthLen+1)) //nolint:gosec
+	copy(request[frameLengthLen:], p[frameLengthLen:])
+	request[len(p)] = 1
+
+	if _, err := c.Conn.Write(request); err != nil {
+		return 0, fmt.Errorf("failed to write connect request: %w", err)
+	}
+
+	return len(p), nil
+}
+
+// Read into the given bytes, detecting the `readOnly` flag of the connect response.
+func (c *readOnlyConn) Read(p []byte) (int, error) {
+	if !c.responseRead {
+		c.responseRead = true
+		if err := c.readConnectResponse(); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(c.pending) > 0 {
+		n := copy(p, c.pending)
+		c.pending = c.pending[n:]
+
+		return n, nil
+	}
+
+	return c.Conn.Read(p) //nolint:wrapcheck
+}
+
+func (c *readOnlyConn) readConnectResponse() error {
+	frameLength := make([]byte, frameLengthLen)
+	if _, err := io.ReadFull(c.Conn, frameLength); err != nil {
+		return fmt.Errorf("failed to read connect response: %w", err)
+	}
+
+	response := make([]byte, binary.BigEndian.Uint32(frameLength))
+	if _, err := io.ReadFull(c.Conn, response); err != nil {
+		return fmt.Errorf("failed to read connect response: %w", err)
+	}
+
+	// The `readOnly` flag follows the password (a length-prefixed byte array)
+	readOnly := false
+	if len(response) >= connectResponseFixedLen+frameLengthLen {
+		passwdLen := int(
+			int32(binary.BigEndian.Uint32(response[connectResponseFixedLen:])),
+		) //nolint:gosec
+		readOnlyIdx := connectResponseFixedLen + frameLengthLen + max(passwdLen, 0)
+		readOnly = len(response) > readOnlyIdx && response[readOnlyIdx] != 0
+	}
+	c.readOnly.Store(readOnly)
+	if readOnly {
+		fmt.Printf("[WARN] Connected to ZooKeeper server %s in read-only mode\n", c.RemoteAddr())
+	}
+
+	c.pending = append(frameLength, response...)
+
+	return nil
+}
+
+// translateReadOnlyError converts the error returned by a read-only ZooKeeper server
+// when attempting a write, into ErrReadOnlyMode.
+func translateReadOnlyError(err error) error {
+	if err != nil && err.Error() == fmt.Sprintf("unknown error: %d", errCodeNotReadOnly) {
+		return ErrReadOnlyMode
+	}
+
+	return err
+}
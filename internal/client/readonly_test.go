@@ -0,0 +1,97 @@
+package client_test
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+	testifyAssert "github.com/stretchr/testify/assert"
+	testifyRequire "github.com/stretchr/testify/require"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+// startReadOnlyServer starts a fake ZooKeeper server in read-only mode, that completes the handshake
+// of each connection, reporting the `canBeReadOnly` flag of the connect request.
+func startReadOnlyServer(t *testing.T) (string, <-chan bool) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	testifyRequire.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	canBeReadOnly := make(chan bool, 16)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+
+				frameLength := make([]byte, 4)
+				if _, err := io.ReadFull(conn, frameLength); err != nil {
+					return
+				}
+				request := make([]byte, binary.BigEndian.Uint32(frameLength))
+				if _, err := io.ReadFull(conn, request); err != nil {
+					return
+				}
+				canBeReadOnly <- request[len(request)-1] == 1
+
+				// protocolVersion, timeOut, sessionId, passwd (length-prefixed), readOnly
+				response := binary.BigEndian.AppendUint32(nil, 0)
+				response = binary.BigEndian.AppendUint32(response, 30000)
+				response = binary.BigEndian.AppendUint64(response, 0x1234)
+				response = binary.BigEndian.AppendUint32(response, 16)
+				response = append(response, make([]byte, 16)...)
+				response = append(response, 1)
+				frame := binary.BigEndian.AppendUint32(nil, uint32(len(response)))
+				if _, err := conn.Write(append(frame, response...)); err != nil {
+					return
+				}
+
+				// Ignore anything else
+				_, _ = io.Copy(io.Discard, conn)
+			}()
+		}
+	}()
+
+	return listener.Addr().String(), canBeReadOnly
+}
+
+func TestAllowReadOnly(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	serverAddr, canBeReadOnly := startReadOnlyServer(t)
+
+	zkClient, err := client.NewClient(&client.Config{
+		Servers:           serverAddr,
+		SessionTimeoutSec: client.DefaultZooKeeperSessionSec,
+		AllowReadOnly:     true,
+	})
+	require.NoError(err)
+	defer zkClient.Close()
+
+	select {
+	case flag := <-canBeReadOnly:
+		assert.True(flag)
+	case <-time.After(5 * time.Second):
+		require.Fail("no connection received by the server")
+	}
+	require.Eventually(zkClient.IsReadOnly, 5*time.Second, 10*time.Millisecond)
+
+	// Writes are refused
+	_, err = zkClient.Create("/test/ReadOnly", nil, zk.WorldACL(zk.PermAll))
+	require.ErrorIs(err, client.ErrReadOnlyMode)
+	_, err = zkClient.Update("/test/ReadOnly", nil, zk.WorldACL(zk.PermAll))
+	require.ErrorIs(err, client.ErrReadOnlyMode)
+	err = zkClient.Delete("/test/ReadOnly")
+	require.ErrorIs(err, client.ErrReadOnlyMode)
+
+	// Syncs are skipped, as read-only servers refuse them
+	require.NoError(zkClient.Sync("/test/ReadOnly"))
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-zookeeper/zk"
+)
+
+var (
+	// ErrReconfigNoParticipants returned when a reconfiguration would leave the ensemble without participants.
+	ErrReconfigNoParticipants = errors.New("the ensemble must have at least one participant")
+
+	// ErrReconfigConfigChanged returned when the configuration of the ensemble has changed,
+	// since the version a reconfiguration is based on.
+	ErrReconfigConfigChanged = errors.New(
+		"ensemble configuration changed since the expected version",
+	)
+
+	// ErrReconfigDisabled returned when the ensemble has dynamic reconfiguration disabled
+	// (see `reconfigEnabled`).
+	ErrReconfigDisabled = zk.ErrReconfigDisabled
+)
+
+// DiffEnsembleMembers computes the incremental reconfiguration (see IncrementalReconfig)
+// that changes the members of the ensemble from current to desired:
+// joining are the members that are new or changed, while leaving are the IDs of the members to remove.
+func DiffEnsembleMembers(current, desired []*EnsembleMember) ([]*EnsembleMember, []int64) {
+	currentByID := make(map[int64]*EnsembleMember, len(current))
+	for _, member := range current {
+		currentByID[member.ID] = member
+	}
+
+	var joining []*EnsembleMember
+	desiredIDs := make(map[int64]bool, len(desired))
+	for _, member := range desired {
+		desiredIDs[member.ID] = true
+		if existing, found := currentByID[member.ID]; !found ||
+			existing.String() != member.String() {
+			joining = append(joining, member)
+		}
+	}
+
+	var leaving []int64
+	for _, member := range current {
+		if !desiredIDs[member.ID] {
+			leaving = append(leaving, member.ID)
+		}
+	}
+
+	return joining, leaving
+}
+
+// CheckReconfigQuorum checks that changing the members of the ensemble from current to desired doesn't lose quorum:
+//
+//   - a majority of the current participants must stay participants, unchanged,
+//     so that the current quorum keeps working while the change is committed
+//   - a majority of the desired participants must be already members of the ensemble (with the same addresses),
+//     so that the new quorum doesn't depend on members that are just joining
+//
+// Changes that don't satisfy this must be split into multiple, smaller, steps:
+// for example, new participants can join as observers first, and then be promoted to participants.
+func CheckReconfigQuorum(current, desired []*EnsembleMember) error {
+	currentByID := make(map[int64]*EnsembleMember, len(current))
+	currentParticipants := 0
+	for _, member := range current {
+		currentByID[member.ID] = member
+		if member.Role == MemberRoleParticipant {
+			currentParticipants++
+		}
+	}
+
+	desiredParticipants, keptParticipants, syncedParticipants := 0, 0, 0
+	for _, member := range desired {
+		if member.Role != MemberRoleParticipant {
+			continue
+		}
+		desiredParticipants++
+
+		existing, found := currentByID[member.ID]
+		if !found || !existing.sameAddresses(member) {
+			continue
+		}
+		syncedParticipants++
+		if existing.Role == MemberRoleParticipant {
+			keptParticipants++
+		}
+	}
+
+	if desiredParticipants == 0 {
+		return ErrReconfigNoParticipants
+	}
+	if keptParticipants <= currentParticipants/2 {
+		return NewReconfigLosesQuorumError(fmt.Sprintf(
+			"only %d of the %d current participants would stay participants, unchanged: a majority is required",
+			keptParticipants,
+			currentParticipants,
+		))
+	}
+	if syncedParticipants <= desiredParticipants/2 {
+		return NewReconfigLosesQuorumError(fmt.Sprintf(
+			"only %d of the %d desired participants are already members of the ensemble: a majority is required "+
+				"(new participants can join as observers first)",
+			syncedParticipants,
+			desiredParticipants,
+		))
+	}
+
+	return nil
+}
+
+// IncrementalReconfig changes the members of the ensemble, via dynamic reconfiguration:
+// joining members are added (or changed, if already members), while the members with the leaving IDs are removed.
+//
+// fromVersion is the version of the configuration the change is based on (see EnsembleConfig.Version):
+// if the configuration has changed since, the change fails with ErrReconfigConfigChanged. Use -1 to skip the check.
+//
+// Reconfiguration is reserved to the ZooKeeper superuser, unless the ensemble is configured otherwise:
+// if configured (see Config.SuperuserPassword), a dedicated connection authenticated as superuser is used.
+//
+// Returns the new configuration of the ensemble.
+func (c *Client) IncrementalReconfig(
+	joining []*EnsembleMember,
+	leaving []int64,
+	fromVersion int64,
+) (*EnsembleConfig, error) {
+	if c.IsReadOnly() {
+		return nil, fmt.Errorf("failed to reconfigure the ensemble: %w", ErrReadOnlyMode)
+	}
+
+	conn := c.zkConn
+	if c.superuser != nil {
+		superuserConn, err := c.superuser()
+		if err != nil {
+			return nil, err
+		}
+		defer superuserConn.Close()
+		conn = superuserConn
+	}
+
+	joiningSpecs := make([]string, 0, len(joining))
+	for _, member := range joining {
+		joiningSpecs = append(joiningSpecs, member.String())
+	}
+	leavingIDs := make([]string, 0, len(leaving))
+	for _, id := range leaving {
+		leavingIDs = append(leavingIDs, strconv.FormatInt(id, 10))
+	}
+
+	fmt.Printf(
+		"[DEBUG] Reconfiguring ensemble from version %x: joining %v, leaving %v\n",
+		fromVersion,
+		joiningSpecs,
+		leavingIDs,
+	)
+	if _, err := conn.IncrementalReconfig(joiningSpecs, leavingIDs, fromVersion); err != nil {
+		if errors.Is(err, zk.ErrBadVersion) {
+			err = ErrReconfigConfigChanged
+		}
+		return nil, fmt.Errorf("failed to reconfigure the ensemble: %w", err)
+	}
+
+	// NOTE: Make sure to read the configuration just committed
+	if _, err := c.zkConn.Sync(EnsembleConfigPath); err != nil {
+		return nil, fmt.Errorf("failed to sync ZNode '%s': %w", EnsembleConfigPath, err)
+	}
+
+	return c.EnsembleConfig()
+}
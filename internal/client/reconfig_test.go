@@ -0,0 +1,118 @@
+package client_test
+
+import (
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	testifyRequire "github.com/stretchr/testify/require"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func member(id int64, role string) *client.EnsembleMember {
+	return &client.EnsembleMember{
+		ID:           id,
+		Host:         "zk-0" + string(rune('0'+id)),
+		QuorumPort:   2888,
+		ElectionPort: 3888,
+		Role:         role,
+		ClientPort:   2181,
+	}
+}
+
+func TestDiffEnsembleMembers(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	current := []*client.EnsembleMember{
+		member(1, client.MemberRoleParticipant),
+		member(2, client.MemberRoleParticipant),
+		member(3, client.MemberRoleParticipant),
+	}
+
+	joining, leaving := client.DiffEnsembleMembers(current, current)
+	assert.Empty(joining)
+	assert.Empty(leaving)
+
+	// Wildcard client addresses are the same as an empty one
+	wildcard := member(2, client.MemberRoleParticipant)
+	wildcard.ClientAddress = "0.0.0.0"
+	joining, leaving = client.DiffEnsembleMembers(current, []*client.EnsembleMember{
+		member(1, client.MemberRoleParticipant),
+		wildcard,
+		member(3, client.MemberRoleParticipant),
+	})
+	assert.Empty(joining)
+	assert.Empty(leaving)
+
+	moved := member(2, client.MemberRoleParticipant)
+	moved.ClientPort = 2182
+	joining, leaving = client.DiffEnsembleMembers(current, []*client.EnsembleMember{
+		member(1, client.MemberRoleParticipant),
+		moved,
+		member(4, client.MemberRoleObserver),
+	})
+	assert.Equal([]*client.EnsembleMember{moved, member(4, client.MemberRoleObserver)}, joining)
+	assert.Equal([]int64{3}, leaving)
+}
+
+func TestCheckReconfigQuorum(t *testing.T) {
+	require := testifyRequire.New(t)
+
+	current := []*client.EnsembleMember{
+		member(1, client.MemberRoleParticipant),
+		member(2, client.MemberRoleParticipant),
+		member(3, client.MemberRoleParticipant),
+		member(4, client.MemberRoleObserver),
+	}
+
+	// Removing a participant, and promoting an observer, keeps the quorum
+	require.NoError(client.CheckReconfigQuorum(current, []*client.EnsembleMember{
+		member(1, client.MemberRoleParticipant),
+		member(2, client.MemberRoleParticipant),
+		member(4, client.MemberRoleParticipant),
+	}))
+
+	// Wildcard client addresses are the same as an empty one
+	wildcards := []*client.EnsembleMember{
+		member(1, client.MemberRoleParticipant),
+		member(2, client.MemberRoleParticipant),
+		member(3, client.MemberRoleParticipant),
+	}
+	wildcards[0].ClientAddress = "0.0.0.0"
+	wildcards[1].ClientAddress = "::"
+	require.NoError(client.CheckReconfigQuorum(current, wildcards))
+
+	// Removing a majority of the participants loses the quorum
+	err := client.CheckReconfigQuorum(current, []*client.EnsembleMember{
+		member(1, client.MemberRoleParticipant),
+		member(4, client.MemberRoleParticipant),
+	})
+	require.ErrorContains(err, "only 1 of the 3 current participants")
+
+	// Adding a majority of new participants loses the quorum
+	err = client.CheckReconfigQuorum(current, []*client.EnsembleMember{
+		member(1, client.MemberRoleParticipant),
+		member(2, client.MemberRoleParticipant),
+		member(3, client.MemberRoleParticipant),
+		member(5, client.MemberRoleParticipant),
+		member(6, client.MemberRoleParticipant),
+		member(7, client.MemberRoleParticipant),
+		member(8, client.MemberRoleParticipant),
+	})
+	require.ErrorContains(err, "only 3 of the 7 desired participants")
+
+	// Growing a single participant ensemble is possible via observers
+	require.NoError(client.CheckReconfigQuorum(
+		[]*client.EnsembleMember{member(1, client.MemberRoleParticipant)},
+		[]*client.EnsembleMember{
+			member(1, client.MemberRoleParticipant),
+			member(2, client.MemberRoleObserver),
+			member(3, client.MemberRoleObserver),
+		},
+	))
+
+	err = client.CheckReconfigQuorum(
+		current,
+		[]*client.EnsembleMember{member(1, client.MemberRoleObserver)},
+	)
+	require.ErrorIs(err, client.ErrReconfigNoParticipants)
+}
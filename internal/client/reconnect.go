@@ -0,0 +1,72 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// reconnectLimiter closes the underlying zk.Conn after too many consecutive failed attempts
+// to (re-)connect to a server: after that, all operations fail with ErrConnectionClosed,
+// instead of waiting for a server to become available again.
+type reconnectLimiter struct {
+	mu          sync.Mutex
+	maxAttempts int
+	attempts    int
+	exhausted   bool
+	conn        *zk.Conn
+}
+
+func newReconnectLimiter(maxAttempts int) *reconnectLimiter {
+	return &reconnectLimiter{
+		maxAttempts: maxAttempts,
+	}
+}
+
+// onEvent is a zk.EventCallback that tracks the connection attempts.
+func (l *reconnectLimiter) onEvent(evt zk.Event) {
+	if l.maxAttempts <= 0 || evt.Type != zk.EventSession {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch evt.State {
+	case zk.StateConnecting:
+		l.attempts++
+		if l.attempts > l.maxAttempts && !l.exhausted {
+			fmt.Printf(
+				"[WARN] Giving up connecting to ZooKeeper after %d attempts (last server: %s)\n",
+				l.maxAttempts,
+				evt.Server,
+			)
+			l.exhausted = true
+			l.closeConn()
+		}
+	case zk.StateHasSession:
+		l.attempts = 0
+	default:
+		// Other states don't affect the count of attempts
+	}
+}
+
+// setConn provides the zk.Conn to close, once available.
+func (l *reconnectLimiter) setConn(conn *zk.Conn) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.conn = conn
+	if l.exhausted {
+		l.closeConn()
+	}
+}
+
+func (l *reconnectLimiter) closeConn() {
+	if l.conn != nil {
+		// NOTE: Events are delivered from within the connection loop,
+		// so closing has to happen asynchronously to avoid deadlocking it.
+		go l.conn.Close()
+	}
+}
@@ -0,0 +1,46 @@
+package client_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	testifyRequire "github.com/stretchr/testify/require"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func TestMaxReconnectAttempts(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	// Reserve a port, then release it, so that nothing is listening on it
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	unreachable := listener.Addr().String()
+	require.NoError(listener.Close())
+
+	zkClient, err := client.NewClient(&client.Config{
+		Servers:              unreachable,
+		SessionTimeoutSec:    client.DefaultZooKeeperSessionSec,
+		ConnectTimeoutSec:    1,
+		MaxReconnectAttempts: 2,
+		ServerOrder:          client.ServerOrderOrdered,
+	})
+	require.NoError(err)
+	defer zkClient.Close()
+
+	// Once the attempts are exhausted, operations fail straight away
+	assert.Eventually(func() bool {
+		_, err := zkClient.Exists("/")
+		return errors.Is(err, client.ErrConnectionClosed)
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestUnsupportedServerOrder(t *testing.T) {
+	_, err := client.NewClient(&client.Config{
+		Servers:     "localhost:2181",
+		ServerOrder: "round-robin",
+	})
+	testifyRequire.ErrorContains(t, err, "unsupported server order 'round-robin'")
+}
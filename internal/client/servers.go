@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// Orders in which servers are connected to.
+const (
+	// ServerOrderRandom picks servers in random order, to avoid creating hotspots. This is the default.
+	ServerOrderRandom = "random"
+	// ServerOrderOrdered picks servers in the order they are listed (ex. local datacenter first).
+	ServerOrderOrdered = "ordered"
+)
+
+// SupportedServerOrders returns the orders in which servers can be connected to.
+func SupportedServerOrders() []string {
+	return []string{ServerOrderRandom, ServerOrderOrdered}
+}
+
+// NormalizeServer validates a ZooKeeper server entry, in the format `host[:port]`,
+// and returns it in the format `host:port`.
+//
+// IPv6 addresses must be enclosed in brackets (ex. `[::1]:2181`).
+// If the port is omitted, the default ZooKeeper client port is used.
+//
+// Example:
+//
+//	NormalizeServer("zk-01")        // -> "zk-01:2181"
+//	NormalizeServer("[::1]:2182")   // -> "[::1]:2182"
+func NormalizeServer(server string) (string, error) {
+	host, port := server, strconv.Itoa(zk.DefaultPort)
+
+	// A port is present if a `:` follows the host (and, in the case of IPv6, the closing bracket)
+	if idx := strings.LastIndexByte(server, ':'); idx > strings.LastIndexByte(server, ']') {
+		if strings.Count(server, ":") > 1 && !strings.HasPrefix(server, "[") {
+			return "", NewInvalidServerError(server, "IPv6 addresses must be enclosed in brackets")
+		}
+		host, port = server[:idx], server[idx+1:]
+	}
+
+	if strings.HasPrefix(host, "[") || strings.HasSuffix(host, "]") {
+		if !strings.HasPrefix(host, "[") || !strings.HasSuffix(host, "]") {
+			return "", NewInvalidServerError(server, "unbalanced brackets")
+		}
+		host = host[1 : len(host)-1]
+		if ip := net.ParseIP(host); ip == nil || ip.To4() != nil {
+			return "", NewInvalidServerError(
+				server,
+				"only IPv6 addresses can be enclosed in brackets",
+			)
+		}
+	}
+
+	if host == "" || strings.ContainsAny(host, " \t/,[]") {
+		return "", NewInvalidServerError(server, "invalid host")
+	}
+
+	if portNum, err := strconv.Atoi(port); err != nil || portNum < 1 || portNum > 65535 {
+		return "", NewInvalidServerError(server, "port must be a number between 1 and 65535")
+	}
+
+	return net.JoinHostPort(host, port), nil
+}
+
+// resolveServers returns the list of servers (in the format `host:port`) and the chroot to connect to.
+//
+// Servers are either listed in the servers connect string (see ParseConnectString),
+// or discovered via DNS SRV record.
+func resolveServers(cfg *Config) ([]string, string, error) {
+	servers, chroot, err := resolveChroot(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if cfg.ServersSRV != "" {
+		if cfg.Servers != "" {
+			return nil, "", ErrServersAndServersSRV
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+		defer cancel()
+
+		servers, err = lookupSRVServers(ctx, cfg.ServersSRV)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	for i, server := range servers {
+		if servers[i], err = NormalizeServer(server); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return servers, chroot, nil
+}
+
+// lookupSRVServers discovers the ZooKeeper servers (in the format `host:port`)
+// from the given DNS SRV record (ex. `_zookeeper._tcp.example.com`).
+func lookupSRVServers(ctx context.Context, srvName string) ([]string, error) {
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, "", "", srvName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup DNS SRV record '%s': %w", srvName, err)
+	}
+
+	servers := make([]string, 0, len(records))
+	for _, record := range records {
+		host := strings.TrimSuffix(record.Target, ".")
+		servers = append(servers, net.JoinHostPort(host, strconv.Itoa(int(record.Port))))
+	}
+
+	if len(servers) == 0 {
+		return nil, NewNoServersFoundError(srvName)
+	}
+
+	return servers, nil
+}
@@ -0,0 +1,43 @@
+package client_test
+
+import (
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func TestNormalizeServer(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for server, expected := range map[string]string{
+		"zk-01":               "zk-01:2181",
+		"zk-01:2182":          "zk-01:2182",
+		"10.0.0.1":            "10.0.0.1:2181",
+		"10.0.0.1:2182":       "10.0.0.1:2182",
+		"[::1]":               "[::1]:2181",
+		"[fe80::1]:2182":      "[fe80::1]:2182",
+		"zk-01.example.com:1": "zk-01.example.com:1",
+	} {
+		normalized, err := client.NormalizeServer(server)
+		if assert.NoError(err, server) {
+			assert.Equal(expected, normalized, server)
+		}
+	}
+
+	for server, expectedErr := range map[string]string{
+		"":                "invalid host",
+		":2181":           "invalid host",
+		"zk-01:":          "port must be a number between 1 and 65535",
+		"zk-01:65536":     "port must be a number between 1 and 65535",
+		"zk-01:port":      "port must be a number between 1 and 65535",
+		"::1":             "IPv6 addresses must be enclosed in brackets",
+		"fe80::1:2181":    "IPv6 addresses must be enclosed in brackets",
+		"[::1:2181":       "unbalanced brackets",
+		"[10.0.0.1]:2181": "only IPv6 addresses can be enclosed in brackets",
+		"zk 01:2181":      "invalid host",
+	} {
+		_, err := client.NormalizeServer(server)
+		assert.ErrorContains(err, expectedErr, server)
+	}
+}
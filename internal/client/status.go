@@ -0,0 +1,195 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ServerStatus is the status of a single server of the ensemble, as reported by
+// the four letter words `srvr`, `mntr`, `cons` and `conf` (or their AdminServer equivalents).
+type ServerStatus struct {
+	Server string
+	// Mode of the server (ex. ServerModeLeader).
+	Mode string
+	// Zxid is the last zxid processed by the server.
+	Zxid int64
+	// Latencies (in milliseconds) of the requests processed by the server.
+	MinLatency int
+	AvgLatency float64
+	MaxLatency int
+	// NodeCount is the number of ZNodes in the data tree of the server.
+	NodeCount           int
+	Connections         int
+	OutstandingRequests int
+	Version             string
+
+	// Monitoring contains all the variables reported by `mntr`.
+	Monitoring map[string]string
+	// Configuration contains the configuration reported by `conf`.
+	Configuration map[string]string
+	// ClientConnections describes each client connection, as reported by `cons`.
+	ClientConnections []string
+
+	// NotAllowedCommands lists the commands the server refused, as not in its `4lw.commands.whitelist`
+	// (or, for the AdminServer, not authorized): the fields they provide are left to their zero-value.
+	NotAllowedCommands []string
+	// Error encountered querying the server, if any (ex. unreachable).
+	Error error
+}
+
+// ServerStatus queries the given server via the four letter words `srvr`, `mntr`, `cons` and `conf`.
+//
+// Commands not whitelisted by the server are skipped, and listed in ServerStatus.NotAllowedCommands.
+// Fields reported by both `srvr` and `mntr` are taken from `srvr`, if allowed.
+//
+// If the Client is configured with an AdminServer URL, the equivalent AdminServer commands are used instead:
+// `server_stats`, `monitor`, `connections` and `configuration`.
+func (c *Client) ServerStatus(server string) *ServerStatus {
+	if c.adminServer != nil {
+		return c.adminServer.serverStatus(server)
+	}
+
+	status := &ServerStatus{Server: server}
+
+	responses := make(map[string]string)
+	for _, command := range []string{"srvr", "mntr", "cons", "conf"} {
+		response, err := c.FourLetterWord(server, command)
+		switch {
+		case errors.Is(err, ErrFourLetterWordNotAllowed):
+			status.NotAllowedCommands = append(status.NotAllowedCommands, command)
+		case err != nil:
+			status.Error = err
+			return status
+		default:
+			responses[command] = response
+		}
+	}
+
+	if mntr, found := responses["mntr"]; found {
+		status.Monitoring = parseFourLetterWordResponse(mntr, "\t")
+		if err := status.setFromMntr(status.Monitoring); err != nil {
+			status.Error = fmt.Errorf("invalid 'mntr' response from '%s': %w", server, err)
+			return status
+		}
+	}
+
+	if srvr, found := responses["srvr"]; found {
+		if err := status.setFromSrvr(parseFourLetterWordResponse(srvr, ":")); err != nil {
+			status.Error = fmt.Errorf("invalid 'srvr' response from '%s': %w", server, err)
+			return status
+		}
+	}
+
+	if conf, found := responses["conf"]; found {
+		status.Configuration = parseFourLetterWordResponse(conf, "=")
+	}
+
+	if cons, found := responses["cons"]; found {
+		for _, line := range strings.Split(cons, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				status.ClientConnections = append(status.ClientConnections, line)
+			}
+		}
+	}
+
+	return status
+}
+
+func (s *ServerStatus) setFromMntr(values map[string]string) error {
+	s.Mode = values["zk_server_state"]
+	s.Version, _, _ = strings.Cut(values["zk_version"], ",")
+
+	var err error
+	if s.AvgLatency, err = parseFloatValue(values["zk_avg_latency"]); err != nil {
+		return err
+	}
+
+	return parseIntValues([]intValue{
+		{values["zk_min_latency"], &s.MinLatency},
+		{values["zk_max_latency"], &s.MaxLatency},
+		{values["zk_znode_count"], &s.NodeCount},
+		{values["zk_num_alive_connections"], &s.Connections},
+		{values["zk_outstanding_requests"], &s.OutstandingRequests},
+	})
+}
+
+func (s *ServerStatus) setFromSrvr(values map[string]string) error {
+	if values["Mode"] == "" {
+		return ErrServerNotServing
+	}
+	s.Mode = values["Mode"]
+	s.Version, _, _ = strings.Cut(values["Zookeeper version"], ",")
+
+	// NOTE: The zxid is in hex format (ex. `0x100000012`)
+	if zxid, found := values["Zxid"]; found {
+		parsed, err := strconv.ParseUint(zxid, 0, 64)
+		if err != nil {
+			return fmt.Errorf("%w: '%s'", ErrUnexpectedCommandResponse, zxid)
+		}
+		s.Zxid = int64(parsed) //nolint:gosec
+	}
+
+	// Latencies are in the format `min/avg/max` (ex. `0/0.1/12`)
+	if latency, found := values["Latency min/avg/max"]; found {
+		latencies := strings.Split(latency, "/")
+		if len(latencies) != 3 {
+			return fmt.Errorf("%w: '%s'", ErrUnexpectedCommandResponse, latency)
+		}
+
+		var err error
+		if s.AvgLatency, err = parseFloatValue(latencies[1]); err != nil {
+			return err
+		}
+		if err = parseIntValues([]intValue{
+			{latencies[0], &s.MinLatency},
+			{latencies[2], &s.MaxLatency},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return parseIntValues([]intValue{
+		{values["Node count"], &s.NodeCount},
+		{values["Connections"], &s.Connections},
+		{values["Outstanding"], &s.OutstandingRequests},
+	})
+}
+
+// intValue is a raw value from a four letter word response, and where to store it once parsed.
+type intValue struct {
+	raw string
+	dst *int
+}
+
+// parseIntValues parses each of the given (non empty) values.
+func parseIntValues(values []intValue) error {
+	for _, value := range values {
+		if value.raw == "" {
+			continue
+		}
+
+		parsed, err := strconv.Atoi(value.raw)
+		if err != nil {
+			return fmt.Errorf("%w: '%s'", ErrUnexpectedCommandResponse, value.raw)
+		}
+		*value.dst = parsed
+	}
+
+	return nil
+}
+
+// parseFloatValue parses the given (maybe empty) value.
+func parseFloatValue(raw string) (float64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: '%s'", ErrUnexpectedCommandResponse, raw)
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,72 @@
+package client_test
+
+import (
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	testifyRequire "github.com/stretchr/testify/require"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func TestServerStatus(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	server := startFourLetterWordServer(t, map[string]string{
+		"srvr": srvrResponse("leader", "3"),
+		"mntr": "zk_version\t3.8.4-9316c2a7a97e1666d8f4593f34dd6fc36ecc436c, built on 2024-02-12 22:16 UTC\n" +
+			"zk_server_state\tleader\n" +
+			"zk_znode_count\t10\n" +
+			"zk_synced_followers\t2\n",
+		"conf": "clientPort=2181\ndataDir=/data\nserverId=1\n",
+	})
+	zkClient := newFourLetterWordTestClient(t, server)
+
+	status := zkClient.ServerStatus(server)
+	require.NoError(status.Error)
+	assert.Equal(server, status.Server)
+	assert.Equal(client.ServerModeLeader, status.Mode)
+	assert.Equal(int64(0x100000012), status.Zxid)
+	assert.Equal(0, status.MinLatency)
+	assert.InDelta(0.1, status.AvgLatency, 0.0001)
+	assert.Equal(12, status.MaxLatency)
+	// `srvr` takes precedence over `mntr`
+	assert.Equal(8, status.NodeCount)
+	assert.Equal(2, status.Connections)
+	assert.Equal(3, status.OutstandingRequests)
+	assert.Equal("3.8.4-9316c2a7a97e1666d8f4593f34dd6fc36ecc436c", status.Version)
+	assert.Equal("2", status.Monitoring["zk_synced_followers"])
+	assert.Equal("/data", status.Configuration["dataDir"])
+	assert.Empty(status.ClientConnections)
+	assert.Equal([]string{"cons"}, status.NotAllowedCommands)
+}
+
+func TestServerStatusOnlyMntr(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+
+	server := startFourLetterWordServer(t, map[string]string{
+		"mntr": "zk_version\t3.9.2\nzk_server_state\tfollower\nzk_avg_latency\t1.5\nzk_znode_count\t10\n",
+		"cons": " /10.0.0.1:51234[1](queued=0,recved=1,sent=1)\n /10.0.0.2:51235[1](queued=0,recved=3,sent=3)\n\n",
+	})
+	zkClient := newFourLetterWordTestClient(t, server)
+
+	status := zkClient.ServerStatus(server)
+	require.NoError(status.Error)
+	assert.Equal(client.ServerModeFollower, status.Mode)
+	assert.Equal("3.9.2", status.Version)
+	assert.InDelta(1.5, status.AvgLatency, 0.0001)
+	assert.Equal(10, status.NodeCount)
+	assert.Zero(status.Zxid)
+	assert.Len(status.ClientConnections, 2)
+	assert.Equal([]string{"srvr", "conf"}, status.NotAllowedCommands)
+}
+
+func TestServerStatusUnreachable(t *testing.T) {
+	require := testifyRequire.New(t)
+
+	server := startFourLetterWordServer(t, map[string]string{})
+	zkClient := newFourLetterWordTestClient(t, server)
+
+	status := zkClient.ServerStatus("127.0.0.1:1")
+	require.Error(status.Error)
+	require.Empty(status.Mode)
+}
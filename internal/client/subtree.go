@@ -0,0 +1,400 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// EphemeralZNode is an ephemeral ZNode, with the ID of the session that owns it.
+type EphemeralZNode struct {
+	Path  string
+	Owner int64
+}
+
+// OwnerHex returns the ID of the owner session in hex format, as reported by ZooKeeper (ex. `0x10000a2b3c40001`).
+//
+// NOTE: Session IDs are unsigned in ZooKeeper, so IDs with the highest bit set (ex. generated by a server
+// with a high `myid`) are not rendered as negative.
+func (e *EphemeralZNode) OwnerHex() string {
+	return "0x" + strconv.FormatUint(uint64(e.Owner), 16)
+}
+
+// SubtreeStats are the statistics of the subtree rooted at a ZNode.
+type SubtreeStats struct {
+	Path string
+	Stat *zk.Stat
+	// DescendantCount is the number of descendants (i.e. excluding the ZNode itself).
+	DescendantCount int64
+	// DataBytes is the total size of the data, of the ZNode and all its descendants.
+	DataBytes int64
+	// Ephemerals are the ephemeral ZNodes of the subtree, in depth-first order.
+	Ephemerals []*EphemeralZNode
+	// Unreadable are the descendants that can't be listed, by ZooKeeper ACL, in depth-first order:
+	// they are not counted, nor are their descendants.
+	Unreadable []string
+	// ExcludedCount is the number of descendants excluded by SubtreeStatsExcluding:
+	// they are counted in DescendantCount too.
+	ExcludedCount int64
+	// ExcludedDataBytes is the total size of the data of the descendants excluded by SubtreeStatsExcluding:
+	// it's counted in DataBytes too.
+	ExcludedDataBytes int64
+}
+
+// SubtreeStats walks the subtree rooted at the ZNode at the given path, and returns its statistics.
+//
+// The tree is always walked, as the Go client doesn't support the `getAllChildrenNumber`
+// and `getEphemerals` operations of ZooKeeper 3.6: this can be slow on large subtrees.
+// ZNodes created or deleted while walking may or may not be counted.
+//
+// If the Client was configured to sync reads (see Config.SyncReads), a Sync is issued first.
+func (c *Client) SubtreeStats(path string) (*SubtreeStats, error) {
+	return c.SubtreeStatsExcluding(path, nil)
+}
+
+// SubtreeStatsExcluding is like SubtreeStats, but it also counts, separately, the descendants
+// for which excluded returns true (ex. the ones managed by someone else), if not nil.
+//
+// Excluded descendants are only counted separately: their own descendants are walked, and counted, as usual.
+func (c *Client) SubtreeStatsExcluding(
+	path string,
+	excluded func(path string) bool,
+) (*SubtreeStats, error) {
+	if c.syncReads {
+		if err := c.Sync(path); err != nil {
+			return nil, err
+		}
+	}
+
+	stats := &SubtreeStats{Path: path}
+	err := c.walkSubtreeSkippingUnreadable(path, func(znodePath string, stat *zk.Stat) error {
+		if znodePath == path {
+			stats.Stat = stat
+		} else {
+			stats.DescendantCount++
+			if excluded != nil && excluded(znodePath) {
+				stats.ExcludedCount++
+				stats.ExcludedDataBytes += int64(stat.DataLength)
+			}
+		}
+
+		stats.DataBytes += int64(stat.DataLength)
+		if stat.EphemeralOwner != 0 {
+			stats.Ephemerals = append(stats.Ephemerals, &EphemeralZNode{
+				Path:  znodePath,
+				Owner: stat.EphemeralOwner,
+			})
+		}
+
+		return nil
+	}, func(znodePath string) {
+		stats.Unreadable = append(stats.Unreadable, znodePath)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// walkSubtree visits, depth-first, the ZNode at the given path and all its descendants,
+// each before its children.
+//
+// Descendants deleted while walking are skipped, while the ZNode at the given path must exist.
+func (c *Client) walkSubtree(path string, visit func(path string, stat *zk.Stat) error) error {
+	return c.walkSubtreeSkippingUnreadable(path, visit, nil)
+}
+
+// walkSubtreeSkippingUnreadable is like walkSubtree, but it also skips the descendants that can't be listed
+// (by ZooKeeper ACL), passing their path to unreadable, if not nil. Otherwise, walking fails on them.
+func (c *Client) walkSubtreeSkippingUnreadable(
+	path string,
+	visit func(path string, stat *zk.Stat) error,
+	unreadable func(path string),
+) error {
+	children, stat, err := c.zkConn.Children(c.absPath(path))
+	if err != nil {
+		return fmt.Errorf("failed to list children for ZNode '%s': %w", path, err)
+	}
+
+	if err := visit(path, stat); err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		err := c.walkSubtreeSkippingUnreadable(childPath(path, child), visit, unreadable)
+		if unreadable != nil && errors.Is(err, zk.ErrNoAuth) {
+			unreadable(childPath(path, child))
+			continue
+		}
+		if err != nil && !errors.Is(err, zk.ErrNoNode) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// childPath returns the path of the child, with the given name, of the ZNode at the given path.
+func childPath(path, child string) string {
+	if path == zNodeRootPath {
+		return path + child
+	}
+
+	return fmt.Sprintf("%s%c%s", path, zNodePathSeparator, child)
+}
+
+// MoveToTrash moves the ZNode at srcPath, and all its descendants, to trashedPath, like MoveSubtree does,
+// except that ephemeral ZNodes are moved too, as persistent ZNodes, as their owner session can't be moved.
+//
+// The missing parents of trashedPath (ex. the root of the trash) are created with parentsACL or,
+// if empty, with the ACL of the ZNode at srcPath, so that the trashed data is not exposed
+// by the trash; the moved ZNodes keep their own ACL.
+//
+// Fails if trashedPath, or any of the ZNodes to move to, already exists.
+func (c *Client) MoveToTrash(srcPath, trashedPath string, parentsACL []zk.ACL) error {
+	if c.IsReadOnly() {
+		return fmt.Errorf("failed to move ZNode '%s' to trash: %w", srcPath, ErrReadOnlyMode)
+	}
+
+	if trashedPath == srcPath ||
+		strings.HasPrefix(trashedPath, srcPath+string(zNodePathSeparator)) ||
+		srcPath == zNodeRootPath {
+		return NewCopyIntoItselfError(srcPath, trashedPath)
+	}
+
+	znodes, err := c.readSubtree(srcPath)
+	if err != nil {
+		return err
+	}
+
+	if len(parentsACL) == 0 {
+		parentsACL = znodes[0].ACL
+	}
+
+	_, err = c.moveSubtree(znodes, srcPath, trashedPath, parentsACL)
+
+	return err
+}
+
+// multiMoveMaxBytes is the maximum estimated size of the `multi` request that moves a subtree atomically:
+// half the default `jute.maxbuffer` (1MB), as ZooKeeper refuses requests larger than that.
+const multiMoveMaxBytes = 512 * 1024
+
+// multiOpOverheadBytes is the estimated size of each operation of a `multi` request, besides paths, data and ACL.
+const multiOpOverheadBytes = 32
+
+// MoveSubtree moves the ZNode at srcPath to dstPath: data and ACL are moved, as well as all its descendants
+// if withDescendants is set, and any necessary parent of dstPath is created.
+//
+// The move is atomic, as a single `multi` request conditional on the version of each ZNode moved,
+// unless the subtree is too large for one request: then, it's copied and deleted one ZNode at a time,
+// and a failure half-way can leave part of the subtree in both places.
+//
+// Fails if dstPath already exists, if the subtree contains ephemeral ZNodes (as their owner session
+// can't be moved), or if withDescendants is not set and the ZNode has children.
+func (c *Client) MoveSubtree(srcPath, dstPath string, withDescendants bool) (*ZNode, error) {
+	if c.IsReadOnly() {
+		return nil, fmt.Errorf("failed to move ZNode '%s': %w", srcPath, ErrReadOnlyMode)
+	}
+
+	if dstPath == srcPath || strings.HasPrefix(dstPath, srcPath+string(zNodePathSeparator)) ||
+		srcPath == zNodeRootPath {
+		return nil, NewCopyIntoItselfError(srcPath, dstPath)
+	}
+
+	znodes, err := c.readSubtreeToMove(srcPath, withDescendants)
+	if err != nil {
+		return nil, err
+	}
+
+	createdParents, err := c.moveSubtree(znodes, srcPath, dstPath, znodes[0].ACL)
+	if err != nil {
+		return nil, err
+	}
+
+	znode, err := c.Read(dstPath)
+	if err != nil {
+		return nil, err
+	}
+	znode.CreatedParents = c.relCreatedParents(createdParents)
+
+	return znode, nil
+}
+
+// moveSubtree moves the given ZNodes (see readSubtreeToMove) from srcPath to dstPath, atomically if possible,
+// creating the missing parents of dstPath with the given ACL. Returns the (absolute) paths of the parents created.
+func (c *Client) moveSubtree(
+	znodes []*ZNode,
+	srcPath, dstPath string,
+	parentsACL []zk.ACL,
+) ([]string, error) {
+	createdParents, err := c.createEmptyZNodes(
+		listParentsInOrder(c.absPath(dstPath)),
+		0,
+		parentsACL,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if estimateMoveBytes(znodes, srcPath, dstPath) <= multiMoveMaxBytes {
+		err = c.moveAtomically(znodes, srcPath, dstPath)
+	} else {
+		fmt.Printf(
+			"[WARN] Subtree of ZNode '%s' is too large to be moved atomically: moving one ZNode at a time\n",
+			srcPath,
+		)
+		err = c.moveOneByOne(znodes, srcPath, dstPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return createdParents, nil
+}
+
+// readSubtreeToMove reads the ZNode at the given path, and its descendants if withDescendants is set,
+// each before its children.
+func (c *Client) readSubtreeToMove(path string, withDescendants bool) ([]*ZNode, error) {
+	if !withDescendants {
+		znode, err := c.doRead(path)
+		if err != nil {
+			return nil, err
+		}
+		if znode.Stat.NumChildren > 0 {
+			return nil, fmt.Errorf("failed to move ZNode '%s': %w", path, ErrZNodeHasChildren)
+		}
+		if znode.Stat.EphemeralOwner != 0 {
+			return nil, NewEphemeralZNodesError(path, []*EphemeralZNode{
+				{Path: path, Owner: znode.Stat.EphemeralOwner},
+			})
+		}
+
+		return []*ZNode{znode}, nil
+	}
+
+	znodes, err := c.readSubtree(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var ephemerals []*EphemeralZNode
+	for _, znode := range znodes {
+		if znode.Stat.EphemeralOwner != 0 {
+			ephemerals = append(ephemerals, &EphemeralZNode{
+				Path:  znode.Path,
+				Owner: znode.Stat.EphemeralOwner,
+			})
+		}
+	}
+	if len(ephemerals) > 0 {
+		return nil, NewEphemeralZNodesError(path, ephemerals)
+	}
+
+	return znodes, nil
+}
+
+// readSubtree reads the ZNode at the given path and all its descendants, each before its children.
+func (c *Client) readSubtree(path string) ([]*ZNode, error) {
+	var znodes []*ZNode
+	err := c.walkSubtree(path, func(znodePath string, _ *zk.Stat) error {
+		znode, err := c.doRead(znodePath)
+		if err != nil {
+			return err
+		}
+
+		znodes = append(znodes, znode)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return znodes, nil
+}
+
+// estimateMoveBytes estimates the size of the `multi` request that moves the given ZNodes.
+func estimateMoveBytes(znodes []*ZNode, srcPath, dstPath string) int {
+	size := 0
+	for _, znode := range znodes {
+		// One create (with data and ACL) and one delete, per ZNode
+		size += 2*multiOpOverheadBytes + len(znode.Data) +
+			len(znode.Path) + len(dstPath) + len(znode.Path) - len(srcPath)
+		for _, acl := range znode.ACL {
+			size += multiOpOverheadBytes + len(acl.Scheme) + len(acl.ID)
+		}
+	}
+
+	return size
+}
+
+// moveAtomically moves the given ZNodes (see readSubtreeToMove) with a single `multi` request:
+// each ZNode is deleted conditionally on the version read, so the move fails as a whole if any
+// ZNode is modified (or any child is added) concurrently.
+func (c *Client) moveAtomically(znodes []*ZNode, srcPath, dstPath string) error {
+	ops := make([]interface{}, 0, 2*len(znodes))
+	for _, znode := range znodes {
+		ops = append(ops, &zk.CreateRequest{
+			Path: c.absPath(dstPath + strings.TrimPrefix(znode.Path, srcPath)),
+			Data: znode.Data,
+			Acl:  znode.ACL,
+		})
+	}
+	for i := len(znodes) - 1; i >= 0; i-- {
+		ops = append(ops, &zk.DeleteRequest{
+			Path:    c.absPath(znodes[i].Path),
+			Version: znodes[i].Stat.Version,
+		})
+	}
+
+	_, err := c.zkConn.Multi(ops...)
+	if err = translateReadOnlyError(err); err != nil {
+		return fmt.Errorf("failed to move ZNode '%s' to '%s': %w", srcPath, dstPath, err)
+	}
+
+	return nil
+}
+
+// moveOneByOne moves the given ZNodes (see readSubtreeToMove) copying them, each before its children,
+// then deleting them, each after its children.
+func (c *Client) moveOneByOne(znodes []*ZNode, srcPath, dstPath string) error {
+	for _, znode := range znodes {
+		copyPath := dstPath + strings.TrimPrefix(znode.Path, srcPath)
+		_, err := c.zkConn.Create(c.absPath(copyPath), znode.Data, 0, znode.ACL)
+		if err = translateReadOnlyError(err); err != nil {
+			return fmt.Errorf("failed to copy ZNode '%s' to '%s': %w", znode.Path, copyPath, err)
+		}
+	}
+
+	for i := len(znodes) - 1; i >= 0; i-- {
+		err := translateReadOnlyError(
+			c.zkConn.Delete(c.absPath(znodes[i].Path), znodes[i].Stat.Version),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to delete moved ZNode '%s': %w", znodes[i].Path, err)
+		}
+	}
+
+	return nil
+}
+
+// CheckNoEphemerals checks that the subtree rooted at the ZNode at the given path has no ephemeral ZNodes:
+// those are owned by live sessions (ex. service registrations, locks), of applications that are still running.
+//
+// Returns an *EphemeralZNodesError listing them otherwise.
+func (c *Client) CheckNoEphemerals(path string) error {
+	stats, err := c.SubtreeStats(path)
+	if err != nil {
+		return err
+	}
+
+	if len(stats.Ephemerals) > 0 {
+		return NewEphemeralZNodesError(path, stats.Ephemerals)
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package client_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+// createEphemeral creates an ephemeral ZNode, owned by a dedicated session that lasts until the end of the test.
+func createEphemeral(t *testing.T, path string) int64 {
+	t.Helper()
+
+	conn, _, err := zk.Connect(
+		strings.Split(os.Getenv(client.EnvZooKeeperServer), ","),
+		10*time.Second,
+		zk.WithLogInfo(false),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(conn.Close)
+
+	if _, err := conn.Create(path, []byte("ephemeral"), zk.FlagEphemeral, zk.WorldACL(zk.PermAll)); err != nil {
+		t.Fatalf("failed to create ephemeral ZNode '%s': %v", path, err)
+	}
+
+	return conn.SessionID()
+}
+
+func TestSubtreeStats(t *testing.T) {
+	zkClient, assert, require := initTest(t)
+	defer zkClient.Close()
+
+	acl := zk.WorldACL(zk.PermAll)
+	_, err := zkClient.Create("/test/SubtreeStats/a/b", []byte("12345"), acl)
+	require.NoError(err)
+	_, err = zkClient.Create("/test/SubtreeStats/c", []byte("123"), acl)
+	require.NoError(err)
+	defer func() {
+		require.NoError(zkClient.Delete("/test"))
+	}()
+
+	stats, err := zkClient.SubtreeStats("/test/SubtreeStats")
+	require.NoError(err)
+	assert.Equal("/test/SubtreeStats", stats.Path)
+	assert.Equal(int64(3), stats.DescendantCount)
+	assert.Equal(int64(8), stats.DataBytes)
+	assert.Equal(int32(2), stats.Stat.NumChildren)
+	assert.Empty(stats.Ephemerals)
+
+	owner := createEphemeral(t, "/test/SubtreeStats/c/ephemeral")
+
+	stats, err = zkClient.SubtreeStats("/test/SubtreeStats")
+	require.NoError(err)
+	assert.Equal(int64(4), stats.DescendantCount)
+	assert.Equal(int64(17), stats.DataBytes)
+	assert.Equal([]*client.EphemeralZNode{
+		{Path: "/test/SubtreeStats/c/ephemeral", Owner: owner},
+	}, stats.Ephemerals)
+
+	// Descendants that can't be listed are skipped
+	t.Setenv(client.EnvZooKeeperUsername, "foo")
+	t.Setenv(client.EnvZooKeeperPassword, "password")
+	fooClient, err := client.NewClientFromEnv()
+	require.NoError(err)
+	defer fooClient.Close()
+
+	_, err = fooClient.Create(
+		"/test/SubtreeStats/a/secret/child",
+		[]byte("123"),
+		zk.DigestACL(zk.PermAll, "foo", "password"),
+	)
+	require.NoError(err)
+	_, err = fooClient.Update(
+		"/test/SubtreeStats/a/secret",
+		nil,
+		zk.DigestACL(zk.PermAll, "foo", "password"),
+	)
+	require.NoError(err)
+
+	stats, err = zkClient.SubtreeStats("/test/SubtreeStats")
+	require.NoError(err)
+	assert.Equal(int64(4), stats.DescendantCount)
+	assert.Equal([]string{"/test/SubtreeStats/a/secret"}, stats.Unreadable)
+
+	require.NoError(fooClient.Delete("/test/SubtreeStats/a/secret"))
+
+	// Excluded descendants are counted separately, while their own descendants are counted as usual
+	stats, err = zkClient.SubtreeStatsExcluding("/test/SubtreeStats", func(path string) bool {
+		return path == "/test/SubtreeStats/a" || path == "/test/SubtreeStats/c"
+	})
+	require.NoError(err)
+	assert.Equal(int64(4), stats.DescendantCount)
+	assert.Equal(int64(2), stats.ExcludedCount)
+	assert.Equal(int64(3), stats.ExcludedDataBytes)
+
+	_, err = zkClient.SubtreeStats("/test/SubtreeStats/missing")
+	require.ErrorIs(err, client.ErrZNodeDoesNotExist)
+}
+
+func TestEphemeralZNodeOwnerHex(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	assert.Equal("0x10000a2b3c40001", (&client.EphemeralZNode{Owner: 0x10000a2b3c40001}).OwnerHex())
+	// Session IDs generated by servers with a high `myid` have the highest bit set
+	assert.Equal(
+		"0xff00000000000001",
+		(&client.EphemeralZNode{Owner: -0xffffffffffffff}).OwnerHex(),
+	)
+}
+
+func TestMoveToTrash(t *testing.T) {
+	t.Setenv(client.EnvZooKeeperUsername, "user")
+	t.Setenv(client.EnvZooKeeperPassword, "pass")
+	zkClient, assert, require := initTest(t)
+	defer zkClient.Close()
+
+	acl := zk.DigestACL(zk.PermAll, "user", "pass")
+	_, err := zkClient.Create(
+		"/test/MoveToTrash/src/child",
+		[]byte("child"),
+		zk.WorldACL(zk.PermAll),
+	)
+	require.NoError(err)
+	_, err = zkClient.Update("/test/MoveToTrash/src", []byte("src"), acl)
+	require.NoError(err)
+	defer func() {
+		require.NoError(zkClient.Delete("/test"))
+	}()
+	createEphemeral(t, "/test/MoveToTrash/src/child/ephemeral")
+
+	var copyIntoItselfErr *client.CopyIntoItselfError
+	err = zkClient.MoveToTrash("/test/MoveToTrash/src", "/test/MoveToTrash/src/.trash/src", nil)
+	require.ErrorAs(err, &copyIntoItselfErr)
+
+	require.NoError(
+		zkClient.MoveToTrash("/test/MoveToTrash/src", "/test/MoveToTrash/.trash/1/src", nil),
+	)
+
+	exists, err := zkClient.Exists("/test/MoveToTrash/src")
+	require.NoError(err)
+	assert.False(exists)
+
+	stats, err := zkClient.SubtreeStats("/test/MoveToTrash/.trash/1/src")
+	require.NoError(err)
+	assert.Equal(int64(2), stats.DescendantCount)
+	assert.Equal(int64(8), stats.DataBytes)
+	// Ephemeral ZNodes are moved as persistent ones
+	assert.Empty(stats.Ephemerals)
+
+	trashed, err := zkClient.Read("/test/MoveToTrash/.trash/1/src")
+	require.NoError(err)
+	assert.Equal([]byte("src"), trashed.Data)
+	assert.Equal(acl, trashed.ACL)
+
+	// The trash has the ACL of the trashed ZNode, unless given
+	trash, err := zkClient.Read("/test/MoveToTrash/.trash")
+	require.NoError(err)
+	assert.Equal(acl, trash.ACL)
+
+	_, err = zkClient.Create("/test/MoveToTrash/src", nil, zk.WorldACL(zk.PermAll))
+	require.NoError(err)
+	err = zkClient.MoveToTrash("/test/MoveToTrash/src", "/test/MoveToTrash/.trash/1/src", nil)
+	require.ErrorIs(err, client.ErrZNodeAlreadyExists)
+
+	trashACL := append(zk.DigestACL(zk.PermAll, "user", "pass"), zk.WorldACL(zk.PermRead)...)
+	require.NoError(
+		zkClient.MoveToTrash("/test/MoveToTrash/src", "/test/MoveToTrash/.trash/2/src", trashACL),
+	)
+	trash, err = zkClient.Read("/test/MoveToTrash/.trash/2")
+	require.NoError(err)
+	assert.Equal(trashACL, trash.ACL)
+
+	err = zkClient.DeleteEmpty("/test/MoveToTrash/.trash/1/src/child")
+	require.ErrorIs(err, client.ErrZNodeHasChildren)
+	require.NoError(zkClient.DeleteEmpty("/test/MoveToTrash/.trash/1/src/child/ephemeral"))
+}
+
+func TestMoveSubtree(t *testing.T) {
+	zkClient, assert, require := initTest(t)
+	defer zkClient.Close()
+
+	acl := zk.DigestACL(zk.PermAll, "user", "pass")
+	_, err := zkClient.Create(
+		"/test/MoveSubtree/src/child",
+		[]byte("child"),
+		zk.WorldACL(zk.PermAll),
+	)
+	require.NoError(err)
+	_, err = zkClient.Update("/test/MoveSubtree/src", []byte("src"), acl)
+	require.NoError(err)
+	defer func() {
+		require.NoError(zkClient.Delete("/test"))
+	}()
+
+	_, err = zkClient.MoveSubtree("/test/MoveSubtree/src", "/test/MoveSubtree/dst/moved", false)
+	require.ErrorIs(err, client.ErrZNodeHasChildren)
+
+	var copyIntoItselfErr *client.CopyIntoItselfError
+	_, err = zkClient.MoveSubtree("/test/MoveSubtree/src", "/test/MoveSubtree/src/moved", true)
+	require.ErrorAs(err, &copyIntoItselfErr)
+
+	moved, err := zkClient.MoveSubtree("/test/MoveSubtree/src", "/test/MoveSubtree/dst/moved", true)
+	require.NoError(err)
+	assert.Equal("/test/MoveSubtree/dst/moved", moved.Path)
+	assert.Equal([]byte("src"), moved.Data)
+	assert.Equal(acl, moved.ACL)
+	assert.Equal([]string{"/test/MoveSubtree/dst"}, moved.CreatedParents)
+
+	child, err := zkClient.Read("/test/MoveSubtree/dst/moved/child")
+	require.NoError(err)
+	assert.Equal([]byte("child"), child.Data)
+
+	exists, err := zkClient.Exists("/test/MoveSubtree/src")
+	require.NoError(err)
+	assert.False(exists)
+
+	moved, err = zkClient.MoveSubtree(
+		"/test/MoveSubtree/dst/moved/child",
+		"/test/MoveSubtree/child",
+		false,
+	)
+	require.NoError(err)
+	assert.Equal([]byte("child"), moved.Data)
+	assert.Empty(moved.CreatedParents)
+
+	createEphemeral(t, "/test/MoveSubtree/dst/moved/instance-1")
+
+	var ephemeralsErr *client.EphemeralZNodesError
+	_, err = zkClient.MoveSubtree("/test/MoveSubtree/dst", "/test/MoveSubtree/elsewhere", true)
+	require.ErrorAs(err, &ephemeralsErr)
+
+	exists, err = zkClient.Exists("/test/MoveSubtree/elsewhere")
+	require.NoError(err)
+	assert.False(exists)
+}
+
+func TestCheckNoEphemerals(t *testing.T) {
+	zkClient, assert, require := initTest(t)
+	defer zkClient.Close()
+
+	_, err := zkClient.Create("/test/CheckNoEphemerals/services", nil, zk.WorldACL(zk.PermAll))
+	require.NoError(err)
+	defer func() {
+		require.NoError(zkClient.Delete("/test"))
+	}()
+
+	require.NoError(zkClient.CheckNoEphemerals("/test/CheckNoEphemerals"))
+
+	owner := createEphemeral(t, "/test/CheckNoEphemerals/services/instance-1")
+
+	err = zkClient.CheckNoEphemerals("/test/CheckNoEphemerals")
+	var ephemeralsErr *client.EphemeralZNodesError
+	require.ErrorAs(err, &ephemeralsErr)
+	assert.Equal([]*client.EphemeralZNode{
+		{Path: "/test/CheckNoEphemerals/services/instance-1", Owner: owner},
+	}, ephemeralsErr.Ephemerals())
+	assert.ErrorContains(err, "'/test/CheckNoEphemerals/services/instance-1' (session 0x")
+}
@@ -1,11 +1,19 @@
 package client
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	"github.com/youmark/pkcs8"
+	"software.sslmate.com/src/go-pkcs12"
 )
 
 // TLSConfig is an internal structure representing TLS-related settings
@@ -15,6 +23,47 @@ type TLSConfig struct {
 	IsEnabled bool
 }
 
+// TLSOptions collects the TLS-related settings necessary to construct a *TLSConfig.
+//
+// The client certificate and key can be provided either as a pair of PEM files
+// (CertFile and KeyFile), or as a single KeyStore file.
+// Similarly, the root CA certificate(s) can be provided either as a PEM file (CAFile),
+// or as a TrustStore file.
+//
+// The KeyStore and TrustStore mirror the Java ZooKeeper client settings
+// `zookeeper.ssl.keyStore.*` and `zookeeper.ssl.trustStore.*`.
+type TLSOptions struct {
+	IsEnabled  bool
+	SkipVerify bool
+
+	CAFile      string
+	CertFile    string
+	KeyFile     string
+	KeyPassword string
+
+	KeyStoreFile     string
+	KeyStorePassword string
+	KeyStoreType     string
+
+	TrustStoreFile     string
+	TrustStorePassword string
+	TrustStoreType     string
+}
+
+// Supported types of KeyStore and TrustStore files.
+//
+// When the type is not specified, it is detected from the file extension.
+const (
+	StoreTypeJKS    = "JKS"
+	StoreTypePKCS12 = "PKCS12"
+	StoreTypePEM    = "PEM"
+)
+
+// SupportedStoreTypes lists all the supported types of KeyStore and TrustStore files.
+func SupportedStoreTypes() []string {
+	return []string{StoreTypeJKS, StoreTypePKCS12, StoreTypePEM}
+}
+
 var (
 	// ErrTLSParseCACert returned when parsing the root CA certificate failed.
 	ErrTLSParseCACert = errors.New("unable to parse TLS root CA cert")
@@ -22,25 +71,51 @@ var (
 	// ErrTLSCertKeyBothOrNone returned when one of either client certificate or client key are specified, but the other is not.
 	ErrTLSCertKeyBothOrNone = errors.New("TLS cert and key file paths are mutually inclusive " +
 		"(if one is specified, the other must be too)")
+
+	// ErrTLSCertKeyAndKeyStore returned when both client certificate/key files and a KeyStore are specified.
+	ErrTLSCertKeyAndKeyStore = errors.New(
+		"TLS cert and key files are mutually exclusive with a TLS keystore",
+	)
+
+	// ErrTLSCAAndTrustStore returned when both a root CA certificate file and a TrustStore are specified.
+	ErrTLSCAAndTrustStore = errors.New(
+		"TLS root CA cert file is mutually exclusive with a TLS truststore",
+	)
+
+	// ErrTLSKeyPasswordRequired returned when the private key is encrypted, but no password was provided.
+	ErrTLSKeyPasswordRequired = errors.New(
+		"TLS private key is encrypted, but no password was provided",
+	)
+
+	// ErrTLSKeyNotFound returned when no private key could be found in the given PEM data.
+	ErrTLSKeyNotFound = errors.New("unable to find a TLS private key in PEM data")
+
+	// ErrTLSKeyStoreNoPrivateKey returned when the KeyStore doesn't contain any private key entry.
+	ErrTLSKeyStoreNoPrivateKey = errors.New("TLS keystore does not contain any private key entry")
+
+	// ErrTLSTrustStoreNoCerts returned when the TrustStore doesn't contain any certificate.
+	ErrTLSTrustStoreNoCerts = errors.New("TLS truststore does not contain any certificate")
 )
 
 // NewTLSConfig reads and parses necessary certs/keys and constructs new *TLSConfig.
-func NewTLSConfig(
-	isEnabled bool,
-	skipVerify bool,
-	caFile string,
-	certFile string,
-	keyFile string,
-) (*TLSConfig, error) { // #nosec G402
+func NewTLSConfig(opts *TLSOptions) (*TLSConfig, error) { // #nosec G402
 	tlsConfig := &TLSConfig{
 		Config: &tls.Config{
-			InsecureSkipVerify: skipVerify,
+			InsecureSkipVerify: opts.SkipVerify,
 		},
-		IsEnabled: isEnabled,
+		IsEnabled: opts.IsEnabled,
+	}
+
+	if opts.CAFile != "" && opts.TrustStoreFile != "" {
+		return nil, ErrTLSCAAndTrustStore
 	}
 
-	if caFile != "" {
-		certPool, err := tlsConfig.readCACert(caFile)
+	if (opts.CertFile != "" || opts.KeyFile != "") && opts.KeyStoreFile != "" {
+		return nil, ErrTLSCertKeyAndKeyStore
+	}
+
+	if opts.CAFile != "" {
+		certPool, err := tlsConfig.readCACert(opts.CAFile)
 		if err != nil {
 			return nil, err
 		}
@@ -48,8 +123,39 @@ func NewTLSConfig(
 		tlsConfig.RootCAs = certPool
 	}
 
-	if certFile != "" || keyFile != "" {
-		certificate, err := tlsConfig.readClientKeyPair(certFile, keyFile)
+	if opts.TrustStoreFile != "" {
+		certPool, err := tlsConfig.readTrustStore(
+			opts.TrustStoreFile,
+			opts.TrustStorePassword,
+			opts.TrustStoreType,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = certPool
+	}
+
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		certificate, err := tlsConfig.readClientKeyPair(
+			opts.CertFile,
+			opts.KeyFile,
+			opts.KeyPassword,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if opts.KeyStoreFile != "" {
+		certificate, err := tlsConfig.readKeyStore(
+			opts.KeyStoreFile,
+			opts.KeyStorePassword,
+			opts.KeyStoreType,
+			opts.KeyPassword,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -75,7 +181,9 @@ func (tlsConfig *TLSConfig) readCACert(caFile string) (*x509.CertPool, error) {
 	return certPool, nil
 }
 
-func (tlsConfig *TLSConfig) readClientKeyPair(certFile, keyFile string) (tls.Certificate, error) {
+func (tlsConfig *TLSConfig) readClientKeyPair(
+	certFile, keyFile, keyPassword string,
+) (tls.Certificate, error) {
 	if certFile == "" || keyFile == "" {
 		return tls.Certificate{}, ErrTLSCertKeyBothOrNone
 	}
@@ -90,6 +198,111 @@ func (tlsConfig *TLSConfig) readClientKeyPair(certFile, keyFile string) (tls.Cer
 		return tls.Certificate{}, fmt.Errorf("unable to read TLS client key file: %w", err)
 	}
 
+	return parsePEMKeyPair(pemCert, pemKey, keyPassword)
+}
+
+func (tlsConfig *TLSConfig) readKeyStore(
+	keyStoreFile, keyStorePassword, keyStoreType, keyPassword string,
+) (tls.Certificate, error) {
+	storeType, err := resolveStoreType(keyStoreFile, keyStoreType)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	storeData, err := os.ReadFile(keyStoreFile) //nolint:gosec
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to read TLS keystore file: %w", err)
+	}
+
+	// Like the Java client, the keystore password also protects
+	// the private key, unless a dedicated key password is given.
+	if keyPassword == "" {
+		keyPassword = keyStorePassword
+	}
+
+	switch storeType {
+	case StoreTypeJKS:
+		return parseJKSKeyStore(storeData, keyStorePassword, keyPassword)
+	case StoreTypePKCS12:
+		return parsePKCS12KeyStore(storeData, keyStorePassword)
+	default:
+		return parsePEMKeyPair(storeData, storeData, keyPassword)
+	}
+}
+
+func (tlsConfig *TLSConfig) readTrustStore(
+	trustStoreFile, trustStorePassword, trustStoreType string,
+) (*x509.CertPool, error) {
+	storeType, err := resolveStoreType(trustStoreFile, trustStoreType)
+	if err != nil {
+		return nil, err
+	}
+
+	if storeType == StoreTypePEM {
+		return tlsConfig.readCACert(trustStoreFile)
+	}
+
+	storeData, err := os.ReadFile(trustStoreFile) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("unable to read TLS truststore file: %w", err)
+	}
+
+	var certs []*x509.Certificate
+	if storeType == StoreTypeJKS {
+		certs, err = parseJKSTrustStore(storeData, trustStorePassword)
+	} else {
+		certs, err = pkcs12.DecodeTrustStore(storeData, trustStorePassword)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse TLS %s truststore: %w", storeType, err)
+	}
+
+	if len(certs) == 0 {
+		return nil, ErrTLSTrustStoreNoCerts
+	}
+
+	certPool := x509.NewCertPool()
+	for _, cert := range certs {
+		certPool.AddCert(cert)
+	}
+
+	return certPool, nil
+}
+
+// resolveStoreType returns the type of the given KeyStore/TrustStore file.
+//
+// If storeType is empty, it is detected from the file extension,
+// the same way the Java ZooKeeper client does.
+func resolveStoreType(storeFile, storeType string) (string, error) {
+	if storeType != "" {
+		for _, t := range SupportedStoreTypes() {
+			if strings.EqualFold(t, storeType) {
+				return t, nil
+			}
+		}
+
+		return "", NewUnsupportedStoreTypeError(storeType)
+	}
+
+	switch strings.ToLower(filepath.Ext(storeFile)) {
+	case ".jks":
+		return StoreTypeJKS, nil
+	case ".p12", ".pfx":
+		return StoreTypePKCS12, nil
+	case ".pem", ".crt", ".cer":
+		return StoreTypePEM, nil
+	default:
+		return "", NewUnsupportedStoreTypeError(filepath.Ext(storeFile))
+	}
+}
+
+// parsePEMKeyPair works like tls.X509KeyPair, but decrypts the private key first if keyPassword is given.
+func parsePEMKeyPair(pemCert, pemKey []byte, keyPassword string) (tls.Certificate, error) {
+	pemKey, err := decryptPEMPrivateKey(pemKey, keyPassword)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
 	certificate, err := tls.X509KeyPair(pemCert, pemKey)
 	if err != nil {
 		return tls.Certificate{}, fmt.Errorf("unable to parse TLS client X509 key pair: %w", err)
@@ -97,3 +310,170 @@ func (tlsConfig *TLSConfig) readClientKeyPair(certFile, keyFile string) (tls.Cer
 
 	return certificate, nil
 }
+
+// decryptPEMPrivateKey finds the private key in the given PEM data and,
+// if it is encrypted, returns it decrypted and re-encoded as PKCS#8 PEM.
+//
+// Both PKCS#8 encrypted keys (`ENCRYPTED PRIVATE KEY`) and legacy
+// OpenSSL encrypted keys (`Proc-Type: 4,ENCRYPTED`) are supported.
+// If the private key is not encrypted, the PEM data is returned untouched.
+func decryptPEMPrivateKey(pemData []byte, password string) ([]byte, error) {
+	rest := pemData
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, ErrTLSKeyNotFound
+		}
+
+		if !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			continue
+		}
+
+		//nolint:staticcheck // Legacy PEM encryption is insecure, but still found in the wild.
+		legacyEncrypted := x509.IsEncryptedPEMBlock(block)
+		if block.Type != "ENCRYPTED PRIVATE KEY" && !legacyEncrypted {
+			return pemData, nil
+		}
+
+		if password == "" {
+			return nil, ErrTLSKeyPasswordRequired
+		}
+
+		var privateKey interface{}
+		var err error
+		if legacyEncrypted {
+			privateKey, err = parseLegacyEncryptedKey(block, password)
+		} else {
+			privateKey, err = pkcs8.ParsePKCS8PrivateKey(block.Bytes, []byte(password))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to decrypt TLS private key: %w", err)
+		}
+
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode decrypted TLS private key: %w", err)
+		}
+
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+	}
+}
+
+func parseLegacyEncryptedKey(block *pem.Block, password string) (interface{}, error) {
+	//nolint:staticcheck // Legacy PEM encryption is insecure, but still found in the wild.
+	der, err := x509.DecryptPEMBlock(block, []byte(password))
+	if err != nil {
+		return nil, fmt.Errorf("legacy PEM decryption failed: %w", err)
+	}
+
+	return parseDERPrivateKey(der)
+}
+
+// parseDERPrivateKey parses a DER encoded private key, trying all the formats Go knows about.
+func parseDERPrivateKey(der []byte) (interface{}, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParseECPrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported private key format: %w", err)
+	}
+
+	return key, nil
+}
+
+// parseJKSKeyStore returns the first private key entry (in alphabetical order of aliases) of a JKS keystore.
+func parseJKSKeyStore(
+	storeData []byte,
+	storePassword, keyPassword string,
+) (tls.Certificate, error) {
+	ks := keystore.New(keystore.WithOrderedAliases())
+	if err := ks.Load(bytes.NewReader(storeData), []byte(storePassword)); err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to parse TLS JKS keystore: %w", err)
+	}
+
+	for _, alias := range ks.Aliases() {
+		if !ks.IsPrivateKeyEntry(alias) {
+			continue
+		}
+
+		entry, err := ks.GetPrivateKeyEntry(alias, []byte(keyPassword))
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf(
+				"unable to read private key '%s' from TLS JKS keystore: %w",
+				alias,
+				err,
+			)
+		}
+
+		privateKey, err := parseDERPrivateKey(entry.PrivateKey)
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf(
+				"unable to parse private key '%s' from TLS JKS keystore: %w",
+				alias,
+				err,
+			)
+		}
+
+		certificate := tls.Certificate{PrivateKey: privateKey}
+		for _, cert := range entry.CertificateChain {
+			certificate.Certificate = append(certificate.Certificate, cert.Content)
+		}
+
+		return certificate, nil
+	}
+
+	return tls.Certificate{}, ErrTLSKeyStoreNoPrivateKey
+}
+
+func parseJKSTrustStore(storeData []byte, storePassword string) ([]*x509.Certificate, error) {
+	ks := keystore.New(keystore.WithOrderedAliases())
+	if err := ks.Load(bytes.NewReader(storeData), []byte(storePassword)); err != nil {
+		return nil, fmt.Errorf("unable to load JKS: %w", err)
+	}
+
+	certs := make([]*x509.Certificate, 0, len(ks.Aliases()))
+	for _, alias := range ks.Aliases() {
+		if !ks.IsTrustedCertificateEntry(alias) {
+			continue
+		}
+
+		entry, err := ks.GetTrustedCertificateEntry(alias)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read certificate '%s': %w", alias, err)
+		}
+
+		cert, err := x509.ParseCertificate(entry.Certificate.Content)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate '%s': %w", alias, err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
+
+func parsePKCS12KeyStore(storeData []byte, storePassword string) (tls.Certificate, error) {
+	privateKey, cert, caCerts, err := pkcs12.DecodeChain(storeData, storePassword)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to parse TLS PKCS12 keystore: %w", err)
+	}
+
+	certificate := tls.Certificate{
+		Certificate: [][]byte{cert.Raw},
+		PrivateKey:  privateKey,
+		Leaf:        cert,
+	}
+	for _, caCert := range caCerts {
+		certificate.Certificate = append(certificate.Certificate, caCert.Raw)
+	}
+
+	return certificate, nil
+}
@@ -0,0 +1,218 @@
+package client_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	testifyAssert "github.com/stretchr/testify/assert"
+	testifyRequire "github.com/stretchr/testify/require"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+	"github.com/youmark/pkcs8"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+const tlsTestPassword = "changeit"
+
+// tlsTestFixture holds a self-signed certificate and its private key, to be stored in various formats.
+type tlsTestFixture struct {
+	dir  string
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newTLSTestFixture(t *testing.T) *tlsTestFixture {
+	t.Helper()
+	require := testifyRequire.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "zookeeper-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(err)
+
+	return &tlsTestFixture{dir: t.TempDir(), key: key, cert: cert}
+}
+
+func (f *tlsTestFixture) write(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(f.dir, name)
+	testifyRequire.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+func (f *tlsTestFixture) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.cert.Raw})
+}
+
+func (f *tlsTestFixture) jks(t *testing.T, withPrivateKey bool) []byte {
+	t.Helper()
+	require := testifyRequire.New(t)
+
+	ks := keystore.New()
+	cert := keystore.Certificate{Type: "X509", Content: f.cert.Raw}
+	if withPrivateKey {
+		keyDER, err := x509.MarshalPKCS8PrivateKey(f.key)
+		require.NoError(err)
+		require.NoError(ks.SetPrivateKeyEntry("client", keystore.PrivateKeyEntry{
+			CreationTime:     time.Now(),
+			PrivateKey:       keyDER,
+			CertificateChain: []keystore.Certificate{cert},
+		}, []byte(tlsTestPassword)))
+	} else {
+		require.NoError(ks.SetTrustedCertificateEntry("ca", keystore.TrustedCertificateEntry{
+			CreationTime: time.Now(),
+			Certificate:  cert,
+		}))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(ks.Store(&buf, []byte(tlsTestPassword)))
+
+	return buf.Bytes()
+}
+
+func TestTLSConfigFromEncryptedPEMKey(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+	f := newTLSTestFixture(t)
+
+	keyDER, err := pkcs8.MarshalPrivateKey(f.key, []byte(tlsTestPassword), nil)
+	require.NoError(err)
+	certFile := f.write(t, "client.pem", f.certPEM())
+	keyFile := f.write(t, "client.key", pem.EncodeToMemory(&pem.Block{
+		Type:  "ENCRYPTED PRIVATE KEY",
+		Bytes: keyDER,
+	}))
+
+	_, err = client.NewTLSConfig(&client.TLSOptions{CertFile: certFile, KeyFile: keyFile})
+	require.ErrorIs(err, client.ErrTLSKeyPasswordRequired)
+
+	tlsConfig, err := client.NewTLSConfig(&client.TLSOptions{
+		CertFile:    certFile,
+		KeyFile:     keyFile,
+		KeyPassword: tlsTestPassword,
+	})
+	require.NoError(err)
+	require.Len(tlsConfig.Certificates, 1)
+	assert.Equal(f.cert.Raw, tlsConfig.Certificates[0].Certificate[0])
+}
+
+func TestTLSConfigFromLegacyEncryptedPEMKey(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+	f := newTLSTestFixture(t)
+
+	keyDER, err := x509.MarshalECPrivateKey(f.key)
+	require.NoError(err)
+	//nolint:staticcheck // Testing support for legacy PEM encryption.
+	block, err := x509.EncryptPEMBlock(
+		rand.Reader,
+		"EC PRIVATE KEY",
+		keyDER,
+		[]byte(tlsTestPassword),
+		x509.PEMCipherAES256,
+	)
+	require.NoError(err)
+
+	tlsConfig, err := client.NewTLSConfig(&client.TLSOptions{
+		CertFile:    f.write(t, "client.pem", f.certPEM()),
+		KeyFile:     f.write(t, "client.key", pem.EncodeToMemory(block)),
+		KeyPassword: tlsTestPassword,
+	})
+	require.NoError(err)
+	require.Len(tlsConfig.Certificates, 1)
+	assert.Equal(f.cert.Raw, tlsConfig.Certificates[0].Certificate[0])
+}
+
+func TestTLSConfigFromJKS(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+	f := newTLSTestFixture(t)
+
+	tlsConfig, err := client.NewTLSConfig(&client.TLSOptions{
+		IsEnabled:          true,
+		KeyStoreFile:       f.write(t, "keystore.jks", f.jks(t, true)),
+		KeyStorePassword:   tlsTestPassword,
+		TrustStoreFile:     f.write(t, "truststore.jks", f.jks(t, false)),
+		TrustStorePassword: tlsTestPassword,
+	})
+	require.NoError(err)
+	require.Len(tlsConfig.Certificates, 1)
+	assert.Equal(f.cert.Raw, tlsConfig.Certificates[0].Certificate[0])
+	assert.Equal(f.key, tlsConfig.Certificates[0].PrivateKey)
+	assert.NotNil(tlsConfig.RootCAs)
+
+	_, err = client.NewTLSConfig(&client.TLSOptions{
+		KeyStoreFile:     f.write(t, "keystore.jks", f.jks(t, true)),
+		KeyStorePassword: "wrong-password",
+	})
+	require.Error(err)
+}
+
+func TestTLSConfigFromPKCS12(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+	f := newTLSTestFixture(t)
+
+	keyStore, err := pkcs12.Modern.Encode(f.key, f.cert, nil, tlsTestPassword)
+	require.NoError(err)
+	trustStore, err := pkcs12.Modern.EncodeTrustStore([]*x509.Certificate{f.cert}, tlsTestPassword)
+	require.NoError(err)
+
+	tlsConfig, err := client.NewTLSConfig(&client.TLSOptions{
+		IsEnabled:          true,
+		KeyStoreFile:       f.write(t, "keystore.store", keyStore),
+		KeyStorePassword:   tlsTestPassword,
+		KeyStoreType:       "pkcs12",
+		TrustStoreFile:     f.write(t, "truststore.p12", trustStore),
+		TrustStorePassword: tlsTestPassword,
+	})
+	require.NoError(err)
+	require.Len(tlsConfig.Certificates, 1)
+	assert.Equal(f.cert.Raw, tlsConfig.Certificates[0].Certificate[0])
+	assert.NotNil(tlsConfig.RootCAs)
+}
+
+func TestTLSConfigStoreErrors(t *testing.T) {
+	assert, require := testifyAssert.New(t), testifyRequire.New(t)
+	f := newTLSTestFixture(t)
+
+	_, err := client.NewTLSConfig(&client.TLSOptions{
+		KeyStoreFile: f.write(t, "keystore.bin", []byte("garbage")),
+	})
+	require.Error(err)
+	assert.Equal(
+		"unsupported TLS keystore/truststore type '.bin' (supported: [JKS PKCS12 PEM])",
+		err.Error(),
+	)
+
+	_, err = client.NewTLSConfig(&client.TLSOptions{
+		CAFile:         f.write(t, "ca.pem", f.certPEM()),
+		TrustStoreFile: f.write(t, "truststore.jks", f.jks(t, false)),
+	})
+	require.ErrorIs(err, client.ErrTLSCAAndTrustStore)
+
+	_, err = client.NewTLSConfig(&client.TLSOptions{
+		KeyStoreFile:     f.write(t, "truststore.jks", f.jks(t, false)),
+		KeyStorePassword: tlsTestPassword,
+	})
+	require.ErrorIs(err, client.ErrTLSKeyStoreNoPrivateKey)
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// WaitCondition is a condition on a ZNode (see Client.WaitFor): the ZNode must exist,
+// and satisfy all the other conditions that are set.
+type WaitCondition struct {
+	// DataEquals, if not nil, must be equal to the data of the ZNode.
+	DataEquals []byte
+	// DataMatches, if not nil, must match the data of the ZNode.
+	DataMatches *regexp.Regexp
+	// MinChildren is the minimum number of children of the ZNode.
+	MinChildren int
+}
+
+// WaitFor waits until the ZNode at the given path satisfies the given WaitCondition, and returns it.
+// Returns a *WaitTimeoutError if it doesn't within the given timeout,
+// or the error of the given context.Context if it's done first.
+//
+// Rather than polling, it watches the ZNode (see `ExistsW`, `GetW` and `ChildrenW`),
+// and checks the condition again whenever a watch fires.
+func (c *Client) WaitFor(
+	ctx context.Context,
+	path string,
+	cond *WaitCondition,
+	timeout time.Duration,
+) (*ZNode, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	unmet := "the server did not respond"
+	for {
+		// NOTE: Requests block while the connection is down (ex. the server is unreachable),
+		// so checking the condition is bounded by the timeout too
+		checked := make(chan waitCheck, 1)
+		go func() {
+			checked <- c.checkWaitConditionAndRead(path, cond)
+		}()
+
+		var check waitCheck
+		select {
+		case check = <-checked:
+		case <-timer.C:
+			return nil, NewWaitTimeoutError(path, timeout, unmet)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped waiting for ZNode '%s': %w", path, ctx.Err())
+		}
+
+		if check.watch == nil {
+			// NOTE: A ZNode deleted right after satisfying the condition is waited for again
+			if errors.Is(check.err, ErrZNodeDoesNotExist) {
+				continue
+			}
+			return check.znode, check.err
+		}
+
+		unmet = check.unmet
+		fmt.Printf("[DEBUG] Waiting for ZNode '%s': %s\n", path, unmet)
+		select {
+		case <-check.watch:
+		case <-timer.C:
+			return nil, NewWaitTimeoutError(path, timeout, unmet)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped waiting for ZNode '%s': %w", path, ctx.Err())
+		}
+	}
+}
+
+// waitCheck is the outcome of checkWaitConditionAndRead.
+type waitCheck struct {
+	znode *ZNode
+	unmet string
+	watch <-chan zk.Event
+	err   error
+}
+
+// checkWaitConditionAndRead checks the given WaitCondition on the ZNode at the given path (see checkWaitCondition)
+// and, if met, reads the ZNode.
+func (c *Client) checkWaitConditionAndRead(path string, cond *WaitCondition) waitCheck {
+	unmet, watch, err := c.checkWaitCondition(path, cond)
+	if err != nil || watch != nil {
+		return waitCheck{unmet: unmet, watch: watch, err: err}
+	}
+
+	znode, err := c.Read(path)
+
+	return waitCheck{znode: znode, err: err}
+}
+
+// checkWaitCondition checks the given WaitCondition on the ZNode at the given path.
+//
+// If unmet, it returns what is unmet, and the watch that fires when it might have changed.
+// Otherwise, the returned watch is nil.
+func (c *Client) checkWaitCondition(
+	path string,
+	cond *WaitCondition,
+) (string, <-chan zk.Event, error) {
+	exists, _, watch, err := c.zkConn.ExistsW(c.absPath(path))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to watch ZNode '%s': %w", path, err)
+	}
+	if !exists {
+		return "it does not exist", watch, nil
+	}
+
+	// NOTE: A ZNode deleted after checking its existence is watched for existence again
+	if cond.MinChildren > 0 {
+		children, _, watch, err := c.zkConn.ChildrenW(c.absPath(path))
+		if errors.Is(err, zk.ErrNoNode) {
+			return c.checkWaitCondition(path, cond)
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to watch children of ZNode '%s': %w", path, err)
+		}
+		if len(children) < cond.MinChildren {
+			return fmt.Sprintf(
+				"it has %d children, instead of at least %d",
+				len(children),
+				cond.MinChildren,
+			), watch, nil
+		}
+	}
+
+	if cond.DataEquals != nil || cond.DataMatches != nil {
+		data, _, watch, err := c.zkConn.GetW(c.absPath(path))
+		if errors.Is(err, zk.ErrNoNode) {
+			return c.checkWaitCondition(path, cond)
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to watch data of ZNode '%s': %w", path, err)
+		}
+		if cond.DataEquals != nil && !bytes.Equal(data, cond.DataEquals) {
+			return "its data is not the expected one", watch, nil
+		}
+		if cond.DataMatches != nil && !cond.DataMatches.Match(data) {
+			return fmt.Sprintf("its data does not match '%s'", cond.DataMatches), watch, nil
+		}
+	}
+
+	return "", nil, nil
+}
@@ -0,0 +1,89 @@
+package client_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func TestWaitFor(t *testing.T) {
+	zkClient, assert, require := initTest(t)
+	defer zkClient.Close()
+
+	_, err := zkClient.Create("/test/WaitFor/workers", nil, zk.WorldACL(zk.PermAll))
+	require.NoError(err)
+	defer func() {
+		require.NoError(zkClient.Delete("/test"))
+	}()
+
+	var timeoutErr *client.WaitTimeoutError
+	_, err = zkClient.WaitFor(
+		t.Context(),
+		"/test/WaitFor/ready",
+		&client.WaitCondition{},
+		100*time.Millisecond,
+	)
+	require.ErrorAs(err, &timeoutErr)
+	assert.ErrorContains(err, "it does not exist")
+
+	// Waiting stops when the context is done
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = zkClient.WaitFor(ctx, "/test/WaitFor/ready", &client.WaitCondition{}, 10*time.Second)
+	require.ErrorIs(err, context.Canceled)
+
+	// The application becomes ready, in steps
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = zkClient.Create("/test/WaitFor/ready", []byte("starting"), zk.WorldACL(zk.PermAll))
+		time.Sleep(100 * time.Millisecond)
+		_, _ = zkClient.Update(
+			"/test/WaitFor/ready",
+			[]byte("ready: v1.2.3"),
+			zk.WorldACL(zk.PermAll),
+		)
+	}()
+
+	ready, err := zkClient.WaitFor(t.Context(), "/test/WaitFor/ready", &client.WaitCondition{
+		DataMatches: regexp.MustCompile(`^ready: `),
+	}, 10*time.Second)
+	require.NoError(err)
+	assert.Equal([]byte("ready: v1.2.3"), ready.Data)
+	assert.Equal(int32(1), ready.Stat.Version)
+
+	ready, err = zkClient.WaitFor(t.Context(), "/test/WaitFor/ready", &client.WaitCondition{
+		DataEquals: []byte("ready: v1.2.3"),
+	}, 10*time.Second)
+	require.NoError(err)
+	assert.Equal("/test/WaitFor/ready", ready.Path)
+
+	_, err = zkClient.WaitFor(t.Context(), "/test/WaitFor/ready", &client.WaitCondition{
+		DataEquals: []byte{},
+	}, 100*time.Millisecond)
+	require.ErrorAs(err, &timeoutErr)
+	assert.ErrorContains(err, "its data is not the expected one")
+
+	// Workers register, one at a time
+	go func() {
+		for _, worker := range []string{"worker-1", "worker-2", "worker-3"} {
+			time.Sleep(50 * time.Millisecond)
+			_, _ = zkClient.Create("/test/WaitFor/workers/"+worker, nil, zk.WorldACL(zk.PermAll))
+		}
+	}()
+
+	workers, err := zkClient.WaitFor(t.Context(), "/test/WaitFor/workers", &client.WaitCondition{
+		MinChildren: 3,
+	}, 10*time.Second)
+	require.NoError(err)
+	assert.Equal(int32(3), workers.Stat.NumChildren)
+
+	_, err = zkClient.WaitFor(t.Context(), "/test/WaitFor/workers", &client.WaitCondition{
+		MinChildren: 4,
+	}, 100*time.Millisecond)
+	require.ErrorAs(err, &timeoutErr)
+	assert.ErrorContains(err, "it has 3 children, instead of at least 4")
+}
@@ -27,6 +27,17 @@ const (
 	zNodeLinkForDesc = "[ZooKeeper ZNode](https://zookeeper.apache.org/doc/current/zookeeperProgrammers.html#sc_zkDataModel_znodes)"
 )
 
+// waitForPropagation waits for a write, that resulted in the given *client.ZNode, to reach every server.
+//
+// Returns the diagnostics to report if it doesn't (i.e. a timeout).
+func waitForPropagation(zkClient *client.Client, znode *client.ZNode) diag.Diagnostics {
+	if err := zkClient.WaitForPropagation(znode); err != nil {
+		return diag.Errorf("Wrote ZNode '%s', but: %v", znode.Path, err)
+	}
+
+	return diag.Diagnostics{}
+}
+
 // setAttributesFromZNode takes a *client.ZNode and populates the *schema.ResourceData with its content.
 func setAttributesFromZNode(
 	rscData *schema.ResourceData,
@@ -179,7 +190,20 @@ func getDataBytesFromResourceData(rscData *schema.ResourceData) ([]byte, error)
 }
 
 func parseACLsFromResourceData(rscData *schema.ResourceData) ([]zk.ACL, error) {
-	aclConfigs := rscData.Get("acl").([]interface{})
+	acls, err := parseACLs(rscData.Get("acl").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(acls) == 0 {
+		acls = zk.WorldACL(zk.PermAll)
+	}
+
+	return acls, nil
+}
+
+// parseACLs parses the given ACL entries (ex. of the `acl` attribute): empty if none is given.
+func parseACLs(aclConfigs []interface{}) ([]zk.ACL, error) {
 	acls := make([]zk.ACL, 0, len(aclConfigs))
 
 	for _, aclConfig := range aclConfigs {
@@ -202,9 +226,5 @@ func parseACLsFromResourceData(rscData *schema.ResourceData) ([]zk.ACL, error) {
 		})
 	}
 
-	if len(acls) == 0 {
-		acls = zk.WorldACL(zk.PermAll)
-	}
-
 	return acls, nil
 }
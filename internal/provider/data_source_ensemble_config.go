@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+const dynamicReconfigLinkForDesc = "[dynamic configuration](https://zookeeper.apache.org/doc/current/zookeeperReconfig.html)"
+
+func datasourceEnsembleConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEnsembleConfigRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Version of the configuration, in hex format " +
+					"(i.e. the zxid of the reconfiguration that created it).",
+			},
+			"raw": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Content of the `" + client.EnsembleConfigPath + "` ZNode, as-is.",
+			},
+			"members": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Members of the ensemble, sorted by ID.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The server ID of the member (i.e. `server.<id>`).",
+						},
+						"host": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The host of the member.",
+						},
+						"quorum_port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The port the member uses to communicate with the leader.",
+						},
+						"election_port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The port the member uses for leader election.",
+						},
+						"role": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The role of the member: either 'participant' or 'observer'.",
+						},
+						"client_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: "The address the member serves clients on. " +
+								"Empty if it serves them on all its addresses.",
+						},
+						"client_port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The port the member serves clients on. Zero if it doesn't serve clients.",
+						},
+						"client_server": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: "The 'host:port' pair clients can connect to the member on " +
+								"(wildcard client addresses are replaced with `host`). Empty if it doesn't serve clients.",
+						},
+						"spec": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: "The member, in the format of the dynamic configuration " +
+								"(ex. `server.1=zk-01:2888:3888:participant;2181`).",
+						},
+					},
+				},
+			},
+			"participants": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the voting members of the ensemble.",
+			},
+			"observers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the observer members of the ensemble.",
+			},
+			"connect_string": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Comma separated list of the `client_server` of each member serving clients: " +
+					"a connection string for the ensemble.",
+			},
+		},
+		Description: "Provides the " + dynamicReconfigLinkForDesc + " of the ZooKeeper ensemble " +
+			"(i.e. its members), parsed from the `" + client.EnsembleConfigPath + "` ZNode. " +
+			"Requires ZooKeeper 3.5 or later.",
+	}
+}
+
+func dataSourceEnsembleConfigRead(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	ensembleCfg, err := zkClient.EnsembleConfig()
+	if err != nil {
+		return diag.Errorf("Unable to read ensemble dynamic configuration: %v", err)
+	}
+
+	members := make([]map[string]interface{}, 0, len(ensembleCfg.Members))
+	participants, observers, clientServers := []int64{}, []int64{}, []string{}
+	for _, member := range ensembleCfg.Members {
+		members = append(members, map[string]interface{}{
+			"id":             member.ID,
+			"host":           member.Host,
+			"quorum_port":    member.QuorumPort,
+			"election_port":  member.ElectionPort,
+			"role":           member.Role,
+			"client_address": member.ClientAddress,
+			"client_port":    member.ClientPort,
+			"client_server":  member.ClientServer(),
+			"spec":           member.String(),
+		})
+
+		if member.Role == client.MemberRoleObserver {
+			observers = append(observers, member.ID)
+		} else {
+			participants = append(participants, member.ID)
+		}
+		if clientServer := member.ClientServer(); clientServer != "" {
+			clientServers = append(clientServers, clientServer)
+		}
+	}
+
+	version := strconv.FormatInt(ensembleCfg.Version, 16)
+
+	// Terraform will use the configuration version as unique identifier for this Data Source
+	rscData.SetId(version)
+
+	diags := diag.Diagnostics{}
+	for attr, value := range map[string]interface{}{
+		"version":        version,
+		"raw":            ensembleCfg.Raw,
+		"members":        members,
+		"participants":   participants,
+		"observers":      observers,
+		"connect_string": strings.Join(clientServers, ","),
+	} {
+		if err := rscData.Set(attr, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
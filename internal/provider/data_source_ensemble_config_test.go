@@ -0,0 +1,95 @@
+package provider_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func TestAccDataSourceEnsembleConfig(t *testing.T) {
+	members := ensembleMembers(t)
+
+	participants, clientServers := 0, []string{}
+	for _, member := range members {
+		if member.Role != client.MemberRoleObserver {
+			participants++
+		}
+		if clientServer := member.ClientServer(); clientServer != "" {
+			clientServers = append(clientServers, clientServer)
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "zookeeper_ensemble_config" "ensemble" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.zookeeper_ensemble_config.ensemble",
+						"version",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.zookeeper_ensemble_config.ensemble",
+						"id",
+						"data.zookeeper_ensemble_config.ensemble",
+						"version",
+					),
+					resource.TestCheckResourceAttrSet(
+						"data.zookeeper_ensemble_config.ensemble",
+						"raw",
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_ensemble_config.ensemble",
+						"members.#",
+						fmt.Sprint(len(members)),
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_ensemble_config.ensemble",
+						"members.0.id",
+						fmt.Sprint(members[0].ID),
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_ensemble_config.ensemble",
+						"members.0.host",
+						members[0].Host,
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_ensemble_config.ensemble",
+						"members.0.client_address",
+						members[0].ClientAddress,
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_ensemble_config.ensemble",
+						"members.0.client_server",
+						members[0].ClientServer(),
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_ensemble_config.ensemble",
+						"members.0.spec",
+						members[0].String(),
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_ensemble_config.ensemble",
+						"participants.#",
+						fmt.Sprint(participants),
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_ensemble_config.ensemble",
+						"observers.#",
+						fmt.Sprint(len(members)-participants),
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_ensemble_config.ensemble",
+						"connect_string",
+						strings.Join(clientServers, ","),
+					),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+const fourLetterWordsLinkForDesc = "[four letter words](https://zookeeper.apache.org/doc/current/zookeeperAdmin.html#sc_4lw)"
+
+func datasourceServerStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceServerStatusRead,
+		Schema: map[string]*schema.Schema{
+			"servers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateServer,
+				},
+				Description: "List of 'host:port' pairs, pointing at the ZooKeeper Server(s) to query. " +
+					"If not set, the servers the provider is configured with are queried.",
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Status of each ZooKeeper Server, in the same order as `servers`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ZooKeeper Server, in the 'host:port' format.",
+						},
+						"mode": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: "Mode of the server: one of 'leader', 'follower', 'observer', " +
+								"'standalone' or 'read-only'.",
+						},
+						"zxid": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The last zxid processed by the server.",
+						},
+						"latency_min": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Minimum latency of the requests processed by the server, in milliseconds.",
+						},
+						"latency_avg": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Average latency of the requests processed by the server, in milliseconds.",
+						},
+						"latency_max": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Maximum latency of the requests processed by the server, in milliseconds.",
+						},
+						"znode_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of ZNodes in the data tree of the server.",
+						},
+						"connections": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of client connections to the server.",
+						},
+						"outstanding_requests": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of requests queued on the server.",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ZooKeeper version of the server.",
+						},
+						"monitoring": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "All the variables reported by `mntr` (or AdminServer `monitor`), " +
+								"ex. `zk_synced_followers`.",
+						},
+						"configuration": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "The configuration of the server, as reported by `conf` " +
+								"(or AdminServer `configuration`).",
+						},
+						"client_connections": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "The client connections to the server, as reported by `cons` " +
+								"(or AdminServer `connections`, JSON encoded).",
+						},
+						"not_allowed_commands": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "The commands the server refused to execute, " +
+								"as not listed in its `4lw.commands.whitelist` (or, for the AdminServer, not authorized): " +
+								"the attributes they provide are left empty.",
+						},
+						"error": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: "The error encountered querying the server (ex. unreachable), if any: " +
+								"the attributes above are then left empty.",
+						},
+					},
+				},
+			},
+		},
+		Description: "Provides the status of each ZooKeeper Server, via the " +
+			fourLetterWordsLinkForDesc + " `srvr`, `mntr`, `cons` and `conf` " +
+			"(or the equivalent AdminServer commands, if the provider is configured with `admin_server_url`). " +
+			"Useful in outputs and `check` blocks. " +
+			"Servers that can't be queried don't fail the data source: their `error` attribute is set instead.",
+	}
+}
+
+func dataSourceServerStatusRead(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	servers := zkClient.Servers()
+	if serverList := rscData.Get("servers").([]interface{}); len(serverList) > 0 {
+		servers = make([]string, 0, len(serverList))
+		for _, server := range serverList {
+			normalized, err := client.NormalizeServer(server.(string))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			servers = append(servers, normalized)
+		}
+	}
+
+	statuses := make([]map[string]interface{}, 0, len(servers))
+	for _, server := range servers {
+		statuses = append(statuses, serverStatusToMap(zkClient.ServerStatus(server)))
+	}
+
+	// Terraform will use the list of servers as unique identifier for this Data Source
+	rscData.SetId(strings.Join(servers, ","))
+
+	diags := diag.Diagnostics{}
+	if err := rscData.Set("servers", servers); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+	if err := rscData.Set("status", statuses); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// serverStatusToMap converts a *client.ServerStatus into a map, as expected by the `status` attribute.
+func serverStatusToMap(status *client.ServerStatus) map[string]interface{} {
+	errMsg := ""
+	if status.Error != nil {
+		errMsg = status.Error.Error()
+	}
+
+	return map[string]interface{}{
+		"server":               status.Server,
+		"mode":                 status.Mode,
+		"zxid":                 status.Zxid,
+		"latency_min":          status.MinLatency,
+		"latency_avg":          status.AvgLatency,
+		"latency_max":          status.MaxLatency,
+		"znode_count":          status.NodeCount,
+		"connections":          status.Connections,
+		"outstanding_requests": status.OutstandingRequests,
+		"version":              status.Version,
+		"monitoring":           status.Monitoring,
+		"configuration":        status.Configuration,
+		"client_connections":   status.ClientConnections,
+		"not_allowed_commands": status.NotAllowedCommands,
+		"error":                errMsg,
+	}
+}
@@ -0,0 +1,41 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceServerStatus(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "zookeeper_server_status" "ensemble" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.zookeeper_server_status.ensemble",
+						"servers.0",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.zookeeper_server_status.ensemble",
+						"status.0.server",
+						"data.zookeeper_server_status.ensemble",
+						"servers.0",
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_server_status.ensemble",
+						"status.0.mode",
+						"standalone",
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_server_status.ensemble",
+						"status.0.error",
+						"",
+					),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func datasourceSubtreeStats() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSubtreeStatsRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Absolute path to the ZNode at the root of the subtree.",
+			},
+			"sync": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Issue a `sync` before walking the subtree. " +
+					"Always done if the provider is configured with `sync_reads`, " +
+					"and skipped while connected to a server in read-only mode.",
+			},
+			"stat": statSchema(),
+			"descendant_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of descendants of the ZNode (i.e. excluding the ZNode itself).",
+			},
+			"data_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total size, in bytes, of the data of the ZNode and all its descendants.",
+			},
+			"unreadable_paths": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Absolute paths to the descendants that the provider is not allowed to list, " +
+					"by ZooKeeper ACL: they are not counted, nor are their descendants.",
+			},
+			"ephemerals": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Ephemeral ZNodes of the subtree (including the ZNode itself, if ephemeral).",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Absolute path to the ephemeral ZNode.",
+						},
+						"ephemeral_owner": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The session id of the owner of the ephemeral ZNode.",
+						},
+						"ephemeral_owner_hex": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: "The session id of the owner of the ephemeral ZNode, in hex format " +
+								"(as reported by ZooKeeper, ex. by the `cons` four letter word).",
+						},
+					},
+				},
+			},
+		},
+		Description: "Provides statistics about the subtree rooted at a " + zNodeLinkForDesc + ": " +
+			"number of descendants, total size of the data and ephemeral ZNodes (with their owner session). " +
+			"Useful to know the impact of deleting, or migrating, a subtree. " +
+			"The subtree is walked one ZNode at a time, so this can be slow on large subtrees. " +
+			"Only the ZNodes the provider is allowed to list, by ZooKeeper ACL, are counted: " +
+			"the others are skipped, and reported in `unreadable_paths`.",
+	}
+}
+
+func dataSourceSubtreeStatsRead(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	znodePath := rscData.Get("path").(string)
+
+	if rscData.Get("sync").(bool) {
+		if err := zkClient.Sync(znodePath); err != nil {
+			return diag.Errorf("Unable to sync ZNode '%s': %v", znodePath, err)
+		}
+	}
+
+	stats, err := zkClient.SubtreeStats(znodePath)
+	if err != nil {
+		return diag.Errorf("Unable to read subtree statistics of '%s': %v", znodePath, err)
+	}
+
+	// Terraform will use the ZNode.Path as unique identifier for this Data Source
+	rscData.SetId(stats.Path)
+
+	ephemerals := make([]map[string]interface{}, 0, len(stats.Ephemerals))
+	for _, ephemeral := range stats.Ephemerals {
+		ephemerals = append(ephemerals, map[string]interface{}{
+			"path":                ephemeral.Path,
+			"ephemeral_owner":     ephemeral.Owner,
+			"ephemeral_owner_hex": ephemeral.OwnerHex(),
+		})
+	}
+
+	diags := diag.Diagnostics{}
+	for attr, value := range map[string]interface{}{
+		"stat":             []interface{}{zNodeStatToMap(&client.ZNode{Path: stats.Path, Stat: stats.Stat})},
+		"descendant_count": stats.DescendantCount,
+		"data_bytes":       stats.DataBytes,
+		"ephemerals":       ephemerals,
+		"unreadable_paths": stats.Unreadable,
+	} {
+		if err := rscData.Set(attr, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,71 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSubtreeStats(t *testing.T) {
+	rootPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:      confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "root" {
+						path = "%s"
+						data = "root"
+					}
+					resource "zookeeper_znode" "grandchild" {
+						path = "${zookeeper_znode.root.path}/child/grandchild"
+						data = "grandchild"
+					}
+					data "zookeeper_subtree_stats" "root" {
+						path = zookeeper_znode.root.path
+						sync = true
+
+						depends_on = [zookeeper_znode.grandchild]
+					}`, rootPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_subtree_stats.root",
+						"id",
+						rootPath,
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_subtree_stats.root",
+						"descendant_count",
+						"2",
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_subtree_stats.root",
+						"data_bytes",
+						"14",
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_subtree_stats.root",
+						"stat.0.num_children",
+						"1",
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_subtree_stats.root",
+						"ephemerals.#",
+						"0",
+					),
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_subtree_stats.root",
+						"unreadable_paths.#",
+						"0",
+					),
+				),
+			},
+		},
+	})
+}
@@ -17,6 +17,15 @@ func datasourceZNode() *schema.Resource {
 				Required:    true,
 				Description: "Absolute path to the ZNode to read.",
 			},
+			"sync": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Issue a `sync` before reading the ZNode, so that the data reflects all the writes " +
+					"committed by the ZooKeeper leader (ex. by another pipeline, right before this read). " +
+					"Always done if the provider is configured with `sync_reads`, " +
+					"and skipped while connected to a server in read-only mode.",
+			},
 			"data": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -73,7 +82,12 @@ func dataSourceZNodeRead(
 
 	znodePath := rscData.Get("path").(string)
 
-	znode, err := zkClient.Read(znodePath)
+	read := zkClient.Read
+	if rscData.Get("sync").(bool) {
+		read = zkClient.SyncRead
+	}
+
+	znode, err := read(znodePath)
 	if err != nil {
 		return diag.Errorf("Unable read ZNode from '%s': %v", znodePath, err)
 	}
@@ -200,3 +200,40 @@ func TestAccDataSourceZNode(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceZNodeSync(t *testing.T) {
+	srcPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:      confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "src" {
+						path = "%s"
+						data = "Forza Napoli!"
+					}
+					data "zookeeper_znode" "dst" {
+						path = zookeeper_znode.src.path
+						sync = true
+					}`, srcPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.zookeeper_znode.dst",
+						"data",
+						"Forza Napoli!",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.zookeeper_znode.dst",
+						"stat.0.mzxid",
+						"zookeeper_znode.src",
+						"stat.0.mzxid",
+					),
+				),
+			},
+		},
+	})
+}
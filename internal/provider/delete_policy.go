@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+// Policies to delete the ZNode of a resource, and its children.
+const (
+	deletePolicyRecursive      = "recursive"
+	deletePolicyFailIfChildren = "fail_if_children"
+	deletePolicyRetain         = "retain"
+	deletePolicyTrash          = "trash"
+	// deletePolicyCopied is only available to `zookeeper_znode_copy`, and its default.
+	deletePolicyCopied = "copied"
+
+	defaultTrashPath = "/.trash"
+
+	// trashTimestampFormat is used to name the trash entry of each deletion: lexicographic order is chronological.
+	trashTimestampFormat = "20060102T150405.000Z"
+)
+
+// deletePolicySchemas provides the *schema.Schema of the attributes that control how a ZNode is deleted.
+func deletePolicySchemas() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"delete_policy": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  deletePolicyRecursive,
+			ValidateFunc: validation.StringInSlice([]string{
+				deletePolicyRecursive,
+				deletePolicyFailIfChildren,
+				deletePolicyRetain,
+				deletePolicyTrash,
+			}, false),
+			Description: "What to do with the ZNode, and its children, when the resource is destroyed: " +
+				"`recursive` deletes the ZNode and all its descendants (including the ones not managed by Terraform); " +
+				"`fail_if_children` deletes the ZNode only if it has no children, failing otherwise; " +
+				"`retain` leaves the ZNode in place, and only removes it from the Terraform state; " +
+				"`trash` moves the ZNode and all its descendants under `trash_path`, so that they can be restored. " +
+				"Defaults to `recursive`.",
+		},
+		"trash_path": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  defaultTrashPath,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^/.*[^/]$`),
+				"must be an absolute ZNode path (i.e. start with '/'), not ending with '/'",
+			),
+			Description: "Absolute path to the ZNode under which the ZNode, and all its descendants, are moved " +
+				"when destroyed with `delete_policy = \"trash\"`: they are moved to " +
+				"`<trash_path>/<timestamp><path>` (ex. `/.trash/20240131T235959.000Z/app/config`), " +
+				"with their data and ACL. The move is atomic, unless the subtree is too large for a single " +
+				"ZooKeeper request. Missing parents (ex. `trash_path` itself) are created with `trash_acl`. " +
+				"Defaults to `/.trash`.",
+		},
+		"trash_acl": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Description: "List of ACL entries for the missing parents (ex. `trash_path` itself) created " +
+				"when moving the ZNode to trash. If not set, they are created with the ACL of the ZNode, " +
+				"so that the trash doesn't expose it: set it when ZNodes with different ACL share " +
+				"the same `trash_path`, as moving to trash requires the permission to create children " +
+				"of the existing parents.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"scheme": {
+						Type:     schema.TypeString,
+						Required: true,
+						Description: "The ACL scheme, such as 'world', 'digest', " +
+							"'ip', 'x509'.",
+					},
+					"id": {
+						Type:     schema.TypeString,
+						Required: true,
+						Description: "The ID for the ACL entry. For example, " +
+							"user:hash in 'digest' scheme.",
+					},
+					"permissions": {
+						Type:     schema.TypeInt,
+						Required: true,
+						Description: "The permissions for the ACL entry, " +
+							"represented as an integer bitmask.",
+					},
+				},
+			},
+		},
+		"created_parents": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Description: "Parents of the ZNode that were missing, and were created (empty) together with it. " +
+				"Not known for imported ZNodes.",
+		},
+		"delete_created_parents": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			Description: "When the ZNode is deleted, delete also the `created_parents`, deepest first, " +
+				"as long as they are unused: i.e. they have no children, and their data and ACL were never " +
+				"modified since created (ex. they are not managed by another resource). " +
+				"Defaults to `false`.",
+		},
+		"delete_ephemerals": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			Description: "Allow deleting (or moving to trash) the ZNode even if its subtree contains ephemeral ZNodes. " +
+				"Ephemeral ZNodes are owned by the live sessions of running applications (ex. service registrations, " +
+				"locks): by default, the deletion is refused, listing them with their owner session. " +
+				"Defaults to `false`.",
+		},
+	}
+}
+
+// withDeletePolicySchemas adds the attributes of deletePolicySchemas (and destroyImpactSchemas)
+// to the given resource attributes.
+func withDeletePolicySchemas(schemas map[string]*schema.Schema) map[string]*schema.Schema {
+	for attr, attrSchema := range deletePolicySchemas() {
+		schemas[attr] = attrSchema
+	}
+	for attr, attrSchema := range destroyImpactSchemas() {
+		schemas[attr] = attrSchema
+	}
+
+	return schemas
+}
+
+// setCreatedParents records in the *schema.ResourceData the parents created together with the ZNode.
+func setCreatedParents(
+	rscData *schema.ResourceData,
+	znode *client.ZNode,
+	diags diag.Diagnostics,
+) diag.Diagnostics {
+	createdParents := znode.CreatedParents
+	if createdParents == nil {
+		createdParents = []string{}
+	}
+
+	if err := rscData.Set("created_parents", createdParents); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// setDeletePolicyDefaults sets the default delete policy attributes, if they are not set (ex. on import),
+// given the default `delete_policy` of the resource.
+func setDeletePolicyDefaults(
+	rscData *schema.ResourceData,
+	defaultDeletePolicy string,
+	diags diag.Diagnostics,
+) diag.Diagnostics {
+	for attr, value := range map[string]string{
+		"delete_policy": defaultDeletePolicy,
+		"trash_path":    defaultTrashPath,
+	} {
+		if rscData.Get(attr).(string) != "" {
+			continue
+		}
+		if err := rscData.Set(attr, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	// NOTE: Explicitly set, so they are in the state even when imported
+	for _, attr := range []string{"delete_ephemerals", "delete_created_parents"} {
+		if err := rscData.Set(attr, rscData.Get(attr).(bool)); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
+
+// checkNoEphemerals refuses to delete the subtree rooted at the given path, if it contains ephemeral ZNodes.
+func checkNoEphemerals(zkClient *client.Client, znodePath string) diag.Diagnostics {
+	err := zkClient.CheckNoEphemerals(znodePath)
+	if err == nil {
+		return diag.Diagnostics{}
+	}
+
+	var ephemeralsErr *client.EphemeralZNodesError
+	if !errors.As(err, &ephemeralsErr) {
+		return diag.Errorf("Failed to check ephemeral ZNodes under '%s': %v", znodePath, err)
+	}
+
+	detail := "The following ephemeral ZNodes are owned by live sessions, of applications that are likely " +
+		"still running:\n"
+	for _, ephemeral := range ephemeralsErr.Ephemerals() {
+		detail += fmt.Sprintf("\n  - %s (session %s)", ephemeral.Path, ephemeral.OwnerHex())
+	}
+	detail += "\n\nStop the applications, or set `delete_ephemerals = true` to delete them anyway."
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary: fmt.Sprintf(
+				"Refusing to delete ZNode '%s': its subtree contains ephemeral ZNodes",
+				znodePath,
+			),
+			Detail: detail,
+		},
+	}
+}
+
+// deleteZNode deletes the ZNode of the resource, according to its `delete_policy`.
+func deleteZNode(zkClient *client.Client, rscData *schema.ResourceData) diag.Diagnostics {
+	znodePath := rscData.Id()
+	managedZNodes.remove(zkClient, znodePath)
+
+	deletePolicy := rscData.Get("delete_policy").(string)
+	if (deletePolicy == deletePolicyRecursive || deletePolicy == deletePolicyTrash) &&
+		!rscData.Get("delete_ephemerals").(bool) {
+		if diags := checkNoEphemerals(zkClient, znodePath); diags.HasError() {
+			return diags
+		}
+	}
+
+	switch deletePolicy {
+	case deletePolicyRetain:
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("ZNode '%s' retained", znodePath),
+				Detail:   "The ZNode has been removed from the Terraform state, but not deleted from ZooKeeper.",
+			},
+		}
+	case deletePolicyFailIfChildren:
+		if err := zkClient.DeleteEmpty(znodePath); err != nil {
+			return diag.Errorf("Failed to delete ZNode '%s': %v", znodePath, err)
+		}
+	case deletePolicyTrash:
+		trashACL, err := parseACLs(rscData.Get("trash_acl").([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		trashedPath := fmt.Sprintf(
+			"%s/%s%s",
+			rscData.Get("trash_path").(string),
+			time.Now().UTC().Format(trashTimestampFormat),
+			znodePath,
+		)
+		if err := zkClient.MoveToTrash(znodePath, trashedPath, trashACL); err != nil {
+			return diag.Errorf("Failed to move ZNode '%s' to trash: %v", znodePath, err)
+		}
+	default:
+		if err := zkClient.Delete(znodePath); err != nil {
+			return diag.Errorf("Failed to delete ZNode '%s': %v", znodePath, err)
+		}
+	}
+
+	return deleteCreatedParents(zkClient, rscData)
+}
+
+// deleteCreatedParents waits for the deletion of the ZNode of the resource, and then deletes
+// its `created_parents`, if `delete_created_parents` is set.
+func deleteCreatedParents(zkClient *client.Client, rscData *schema.ResourceData) diag.Diagnostics {
+	znodePath := rscData.Id()
+
+	if err := zkClient.WaitForDeletion(znodePath); err != nil {
+		return diag.Errorf("Deleted ZNode '%s', but: %v", znodePath, err)
+	}
+
+	if rscData.Get("delete_created_parents").(bool) {
+		createdParents := make([]string, 0)
+		for _, parent := range rscData.Get("created_parents").([]interface{}) {
+			createdParents = append(createdParents, parent.(string))
+		}
+
+		if _, err := zkClient.DeleteUnusedParents(createdParents); err != nil {
+			return diag.Errorf(
+				"Deleted ZNode '%s', but not its created parents: %v",
+				znodePath,
+				err,
+			)
+		}
+	}
+
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+// managedZNodes are the ZNodes known to be managed by resources of the provider (i.e. created, read or updated
+// by them, as the provider runs): ZooKeeper doesn't know which ZNodes are managed by Terraform.
+var managedZNodes = &znodeRegistry{
+	paths: make(map[znodeRegistryKey]struct{}),
+} //nolint:gochecknoglobals
+
+type znodeRegistryKey struct {
+	zkClient *client.Client
+	path     string
+}
+
+// znodeRegistry is a set of ZNode paths, for each *client.Client, safe for concurrent use.
+type znodeRegistry struct {
+	mu    sync.Mutex
+	paths map[znodeRegistryKey]struct{}
+}
+
+func (r *znodeRegistry) add(zkClient *client.Client, paths ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, path := range paths {
+		r.paths[znodeRegistryKey{zkClient, path}] = struct{}{}
+	}
+}
+
+func (r *znodeRegistry) remove(zkClient *client.Client, path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.paths, znodeRegistryKey{zkClient, path})
+}
+
+func (r *znodeRegistry) contains(zkClient *client.Client, path string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, found := r.paths[znodeRegistryKey{zkClient, path}]
+
+	return found
+}
+
+// destroyImpactSchemas provides the *schema.Schema of the attributes that preview the impact of destroying a ZNode.
+func destroyImpactSchemas() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"track_destroy_impact": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+			Description: "Track, in `destroy_impact`, what destroying the ZNode would delete, and warn when " +
+				"refreshing (ex. on `terraform plan`) if `delete_policy = \"recursive\"` would delete " +
+				"unmanaged descendants: this walks the subtree of the ZNode on every refresh, " +
+				"so it can be slow on large subtrees. Defaults to `true`.",
+		},
+		"destroy_impact": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Description: "What destroying (or replacing) the ZNode would delete, as of the last refresh: " +
+				"as it's shown in the plan, it previews the impact of destroying the ZNode. " +
+				"Only tracked if `track_destroy_impact` is set.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"unmanaged_descendant_count": {
+						Type:     schema.TypeInt,
+						Computed: true,
+						Description: "Number of descendants of the ZNode not managed by another resource " +
+							"(ex. created by applications at runtime). ZooKeeper doesn't know which ZNodes " +
+							"are managed by Terraform: the ones managed by resources refreshed after this one " +
+							"(ex. depending on it) are counted as unmanaged.",
+					},
+					"unmanaged_data_bytes": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Total size, in bytes, of the data of the unmanaged descendants of the ZNode.",
+					},
+					"total_descendant_count": {
+						Type:     schema.TypeInt,
+						Computed: true,
+						Description: "Number of descendants of the ZNode, in total: this includes the ones " +
+							"managed by other resources (ex. other `zookeeper_znode`).",
+					},
+					"data_bytes": {
+						Type:     schema.TypeInt,
+						Computed: true,
+						Description: "Total size, in bytes, of the data of the ZNode and all its descendants " +
+							"(including the ones managed by other resources).",
+					},
+					"ephemeral_count": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Number of ephemeral ZNodes in the subtree.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// setDestroyImpact populates the `destroy_impact` of the *schema.ResourceData, if tracked.
+//
+// It also records the ZNode of the resource as managed (see managedZNodes),
+// so that it's not counted in the `destroy_impact` of its ancestors.
+func setDestroyImpact(
+	zkClient *client.Client,
+	rscData *schema.ResourceData,
+	diags diag.Diagnostics,
+) diag.Diagnostics {
+	managedZNodes.add(zkClient, rscData.Id())
+
+	destroyImpact := []interface{}{}
+
+	if rscData.Get("track_destroy_impact").(bool) {
+		stats, err := zkClient.SubtreeStatsExcluding(rscData.Id(), func(path string) bool {
+			return managedZNodes.contains(zkClient, path)
+		})
+		if err != nil {
+			return append(diags, diag.Errorf("Failed to track destroy impact: %v", err)...)
+		}
+
+		destroyImpact = append(destroyImpact, map[string]interface{}{
+			"unmanaged_descendant_count": stats.DescendantCount - stats.ExcludedCount,
+			"unmanaged_data_bytes": stats.DataBytes - int64(
+				stats.Stat.DataLength,
+			) - stats.ExcludedDataBytes,
+			"total_descendant_count": stats.DescendantCount,
+			"data_bytes":             stats.DataBytes,
+			"ephemeral_count":        len(stats.Ephemerals),
+		})
+	}
+
+	if err := rscData.Set("destroy_impact", destroyImpact); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	// NOTE: Explicitly set, so it's in the state even when imported
+	if err := rscData.Set("track_destroy_impact", rscData.Get("track_destroy_impact").(bool)); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// setDestroyImpactDefaults sets `track_destroy_impact` to its default, if it's not set (ex. on import).
+func setDestroyImpactDefaults(
+	rscData *schema.ResourceData,
+	diags diag.Diagnostics,
+) diag.Diagnostics {
+	rawState := rscData.GetRawState()
+	if rawState.IsNull() || !rawState.GetAttr("track_destroy_impact").IsNull() {
+		return diags
+	}
+
+	if err := rscData.Set("track_destroy_impact", true); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// warnDestroyImpact warns if destroying the ZNode of the resource would delete unmanaged descendants,
+// as tracked in its `destroy_impact`.
+//
+// NOTE: Terraform doesn't allow CustomizeDiff to return warnings: this is meant to be called when refreshing
+// (i.e. on Read), so that the warning is shown by `terraform plan` (and `terraform destroy`).
+func warnDestroyImpact(rscData *schema.ResourceData, diags diag.Diagnostics) diag.Diagnostics {
+	destroyImpact := rscData.Get("destroy_impact").([]interface{})
+	if len(destroyImpact) == 0 || rscData.Get("delete_policy").(string) != deletePolicyRecursive {
+		return diags
+	}
+
+	impact := destroyImpact[0].(map[string]interface{})
+	unmanagedCount := impact["unmanaged_descendant_count"].(int)
+	if unmanagedCount == 0 {
+		return diags
+	}
+
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary: fmt.Sprintf(
+			"Destroying ZNode '%s' would delete %d unmanaged descendants",
+			rscData.Id(),
+			unmanagedCount,
+		),
+		Detail: fmt.Sprintf(
+			"%d descendants of the ZNode (%d bytes of data) are not managed by other resources: "+
+				"with `delete_policy = \"recursive\"`, destroying or replacing the ZNode deletes them too.\n\n"+
+				"Set `delete_policy` to `fail_if_children`, `retain` or `trash` to protect them, "+
+				"or `track_destroy_impact = false` to stop tracking them.",
+			unmanagedCount,
+			impact["unmanaged_data_bytes"].(int),
+		),
+	})
+}
+
+// customizeDiffDestroyImpact returns a schema.CustomizeDiffFunc that checks, at plan time, the impact of replacing
+// the ZNode, when the given (ForceNew) attribute changes. It also plans `destroy_impact` to be recomputed,
+// when `track_destroy_impact` changes.
+//
+// NOTE: Terraform doesn't call CustomizeDiff when planning to destroy a resource,
+// nor allows it to return warnings: the impact of destroying (or replacing) a ZNode is warned about
+// when refreshing (see warnDestroyImpact), while replacing a ZNode that would fail because of ephemeral ZNodes
+// (see `delete_ephemerals`) fails the plan, instead of the apply.
+func customizeDiffDestroyImpact(forceNewAttr string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, prvClient interface{}) error {
+		if diff.HasChange("track_destroy_impact") {
+			if err := diff.SetNewComputed("destroy_impact"); err != nil {
+				return fmt.Errorf("failed to plan 'destroy_impact': %w", err)
+			}
+		}
+
+		zkClient, ok := prvClient.(*client.Client)
+		if !ok || diff.Id() == "" || !diff.HasChange(forceNewAttr) {
+			return nil
+		}
+
+		znodePath := diff.Id()
+		stats, err := zkClient.SubtreeStats(znodePath)
+		if err != nil {
+			return fmt.Errorf(
+				"unable to preview the impact of replacing ZNode '%s': %w",
+				znodePath,
+				err,
+			)
+		}
+
+		deletePolicy := diff.Get("delete_policy").(string)
+		if len(stats.Ephemerals) > 0 && !diff.Get("delete_ephemerals").(bool) &&
+			(deletePolicy == deletePolicyRecursive || deletePolicy == deletePolicyTrash) {
+			return fmt.Errorf(
+				"replacing ZNode '%s' would fail, set `delete_ephemerals = true` to allow it: %w",
+				znodePath,
+				client.NewEphemeralZNodesError(znodePath, stats.Ephemerals),
+			)
+		}
+
+		return nil
+	}
+}
@@ -2,9 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
 )
 
@@ -16,25 +20,240 @@ func New() (*schema.Provider, error) {
 
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
-			"servers": {
+			"client_config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   false,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperClientConfigFile, nil),
+				Description: "File path to a ZooKeeper Java client configuration file " +
+					"(i.e. `zookeeper-client.properties`), used to configure servers, TLS and authentication. " +
+					"Settings configured explicitly on the provider take precedence over the ones in the file. " +
+					"More information about the supported keys can be found [here](#client-configuration-file). " +
+					"Can be set via `ZOOKEEPER_CLIENT_CONFIG_FILE` environment variable.",
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   false,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperProfile, nil),
+				Description: "Name of the connection profile to use, from the file set via `profiles_file`. " +
+					"Settings configured explicitly on the provider take precedence over the ones in the profile. " +
+					"More information about connection profiles can be found [here](#connection-profiles). " +
+					"Can be set via `ZOOKEEPER_PROFILE` environment variable.",
+			},
+			"profiles_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   false,
-				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperServer, nil),
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperProfilesFile, nil),
+				Description: "File path to the connection profiles file. Defaults to `~/.zookeeper/profiles.toml`. " +
+					"Can be set via `ZOOKEEPER_PROFILES_FILE` environment variable.",
+			},
+			"servers": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     false,
+				DefaultFunc:   schema.EnvDefaultFunc(client.EnvZooKeeperServer, nil),
+				ValidateFunc:  validateConnectString,
+				ConflictsWith: []string{"server_list", "servers_srv"},
 				Description: "A comma separated list of 'host:port' pairs, pointing at ZooKeeper Server(s). " +
 					"Can be set via `ZOOKEEPER_SERVERS` environment variable.",
 			},
+			"server_list": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateServer,
+				},
+				ConflictsWith: []string{"servers", "servers_srv"},
+				Description: "A list of 'host:port' pairs, pointing at ZooKeeper Server(s): " +
+					"alternative to `servers`, validated at plan time. " +
+					"IPv6 addresses must be enclosed in brackets (ex. `[::1]:2181`). " +
+					"If the port is omitted, `2181` is used.",
+			},
+			"servers_srv": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     false,
+				DefaultFunc:   schema.EnvDefaultFunc(client.EnvZooKeeperServersSRV, nil),
+				ConflictsWith: []string{"servers", "server_list"},
+				Description: "Name of the DNS SRV record (ex. `_zookeeper._tcp.example.com`) " +
+					"listing the ZooKeeper Server(s): alternative to `servers`. " +
+					"More information about servers discovery can be found [here](#servers-discovery). " +
+					"Can be set via `ZOOKEEPER_SERVERS_SRV` environment variable.",
+			},
 			"session_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Sensitive:   false,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperSessionSec, nil),
+				Description: "How many seconds a session is considered valid after losing connectivity. " +
+					"More information about ZooKeeper sessions can be found [here](#zookeeper-sessions). " +
+					"Can be set via `ZOOKEEPER_SESSION` environment variable.",
+			},
+			"connect_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Sensitive:    false,
+				DefaultFunc:  schema.EnvDefaultFunc(client.EnvZooKeeperConnectTimeoutSec, nil),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "How many seconds to wait when establishing a connection to a ZooKeeper Server. " +
+					"Defaults to `1`. " +
+					"Can be set via `ZOOKEEPER_CONNECT_TIMEOUT` environment variable.",
+			},
+			"max_reconnect_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Sensitive:    false,
+				DefaultFunc:  schema.EnvDefaultFunc(client.EnvZooKeeperMaxReconnectAttempts, nil),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "How many consecutive failed attempts to connect to a ZooKeeper Server are allowed, " +
+					"before giving up: after that, every operation fails. " +
+					"Defaults to `0` (i.e. unlimited). " +
+					"Can be set via `ZOOKEEPER_MAX_RECONNECT_ATTEMPTS` environment variable.",
+			},
+			"max_buffer_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Sensitive:    false,
+				DefaultFunc:  schema.EnvDefaultFunc(client.EnvZooKeeperMaxBufferSize, nil),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Maximum size, in bytes, of the packets sent to, and received from, ZooKeeper " +
+					"(equivalent to the `jute.maxbuffer` setting of the Java client): " +
+					"set it to write, or read, ZNodes larger than the default allows. " +
+					"If not set, received packets are not limited, while sent packets are limited " +
+					"to 1.5 MiB (i.e. `1572864`). " +
+					"Can be set via `ZOOKEEPER_MAX_BUFFER_SIZE` environment variable.",
+			},
+			"sync_reads": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Sensitive:   false,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperSyncReads, nil),
+				Description: "Issue a `sync` before every read (including the refresh of resources), " +
+					"so that plans reflect all the writes committed by the ZooKeeper leader, " +
+					"even if the server the provider is connected to is lagging behind. " +
+					"Skipped while connected to a server in read-only mode (see `allow_read_only`), " +
+					"as those refuse to `sync`. " +
+					"More information about consistency can be found [here](#read-consistency). " +
+					"Can be set via `ZOOKEEPER_SYNC_READS` environment variable.",
+			},
+			"allow_read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Sensitive:   false,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperAllowReadOnly, nil),
+				Description: "Allow connecting to ZooKeeper Server(s) in read-only mode (i.e. `canBeReadOnly`), " +
+					"so that data sources and refresh keep working when the ensemble has lost quorum. " +
+					"Writes are refused while connected to a read-only server, " +
+					"and reads are not synced (see `sync_reads`), so they might be stale. " +
+					"More information about read-only mode can be found [here](#read-only-mode). " +
+					"Can be set via `ZOOKEEPER_ALLOW_READ_ONLY` environment variable.",
+			},
+			"wait_for_propagation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Sensitive:   false,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperWaitForPropagation, nil),
+				Description: "After each write, wait for it to reach every ZooKeeper Server, " +
+					"before considering the resource created/updated/deleted. " +
+					"More information about propagation can be found [here](#waiting-for-propagation). " +
+					"Can be set via `ZOOKEEPER_WAIT_FOR_PROPAGATION` environment variable.",
+			},
+			"propagation_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Sensitive:    false,
+				DefaultFunc:  schema.EnvDefaultFunc(client.EnvZooKeeperPropagationTimeoutSec, nil),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "How many seconds to wait for a write to reach every ZooKeeper Server, " +
+					"when `wait_for_propagation` is set. Defaults to `30`. " +
+					"Can be set via `ZOOKEEPER_PROPAGATION_TIMEOUT` environment variable.",
+			},
+			"health_check": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Sensitive:   false,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperHealthCheck, nil),
+				Description: "Check the health of the ZooKeeper ensemble when configuring the provider, " +
+					"and refuse to use it if degraded. " +
+					"More information about health checks can be found [here](#ensemble-health-check). " +
+					"Can be set via `ZOOKEEPER_HEALTH_CHECK` environment variable.",
+			},
+			"health_check_min_quorum": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Sensitive:    false,
+				DefaultFunc:  schema.EnvDefaultFunc(client.EnvZooKeeperHealthCheckMinQuorum, nil),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Minimum number of healthy voting ZooKeeper Servers (i.e. leader and followers), " +
+					"when `health_check` is set. Defaults to a majority of the servers. " +
+					"Can be set via `ZOOKEEPER_HEALTH_CHECK_MIN_QUORUM` environment variable.",
+			},
+			"health_check_max_outstanding_requests": {
 				Type:      schema.TypeInt,
 				Optional:  true,
 				Sensitive: false,
 				DefaultFunc: schema.EnvDefaultFunc(
-					client.EnvZooKeeperSessionSec,
-					client.DefaultZooKeeperSessionSec,
+					client.EnvZooKeeperHealthCheckMaxOutstandingRequests,
+					nil,
 				),
-				Description: "How many seconds a session is considered valid after losing connectivity. " +
-					"More information about ZooKeeper sessions can be found [here](#zookeeper-sessions). " +
-					"Can be set via `ZOOKEEPER_SESSION` environment variable.",
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Maximum number of outstanding requests of each ZooKeeper Server, " +
+					"when `health_check` is set. Not checked if not set. " +
+					"Can be set via `ZOOKEEPER_HEALTH_CHECK_MAX_OUTSTANDING_REQUESTS` environment variable.",
+			},
+			"server_order": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    false,
+				DefaultFunc:  schema.EnvDefaultFunc(client.EnvZooKeeperServerOrder, nil),
+				ValidateFunc: validation.StringInSlice(client.SupportedServerOrders(), false),
+				Description: "Order in which the ZooKeeper Server(s) are connected to: " +
+					"`random` (default, to avoid creating hotspots) " +
+					"or `ordered` (i.e. in the order they are listed, ex. local datacenter first). " +
+					"Can be set via `ZOOKEEPER_SERVER_ORDER` environment variable.",
+			},
+			"chroot": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   false,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperChroot, nil),
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^/`),
+					"must be an absolute ZNode path (i.e. start with '/')",
+				),
+				Description: "ZNode path that all the ZNode paths managed by this provider are relative to. " +
+					"Equivalent to the chroot suffix of a ZooKeeper connect string (ex. `zk-01:2181/app`), " +
+					"that is also supported by `servers`. " +
+					"More information about chroot can be found [here](#chroot). " +
+					"Can be set via `ZOOKEEPER_CHROOT` environment variable.",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc(client.EnvZooKeeperProxyURL, nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"socks5", "socks5h"}),
+				Description: "URL of a SOCKS5 proxy (ex. a bastion host) to connect to the ZooKeeper server(s) through, " +
+					"in the format `socks5://[user:password@]host[:port]`. " +
+					"If not set, the `ALL_PROXY` environment variable is honoured (alongside `NO_PROXY`). " +
+					"More information about proxies can be found [here](#connecting-through-a-proxy). " +
+					"Can be set via `ZOOKEEPER_PROXY_URL` environment variable.",
+			},
+			"admin_server_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    false,
+				DefaultFunc:  schema.EnvDefaultFunc(client.EnvZooKeeperAdminServerURL, nil),
+				ValidateFunc: validateAdminServerURL,
+				Description: "URL of the ZooKeeper AdminServer commands, where `{host}` is replaced with the host " +
+					"of each server (ex. `https://{host}:8080/commands`). When set, the AdminServer is used " +
+					"instead of four letter words (ex. by `health_check` and data source `zookeeper_server_status`). " +
+					"More information about the AdminServer can be found [here](#adminserver). " +
+					"Can be set via `ZOOKEEPER_ADMIN_SERVER_URL` environment variable.",
 			},
 			"username": {
 				Type:        schema.TypeString,
@@ -52,6 +271,24 @@ func New() (*schema.Provider, error) {
 				Description: "Password for digest authentication. " +
 					"Can be set via `ZOOKEEPER_PASSWORD` environment variable.",
 			},
+			"superuser_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperSuperuserUsername, nil),
+				Description: "Username of the ZooKeeper superuser, used for the operations that require it " +
+					"(ex. resource `zookeeper_ensemble_membership`). Defaults to `super`. " +
+					"Can be set via `ZOOKEEPER_SUPERUSER_USERNAME` environment variable.",
+			},
+			"superuser_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperSuperuserPassword, nil),
+				Description: "Password of the ZooKeeper superuser. " +
+					"More information about the superuser can be found [here](#ensemble-membership). " +
+					"Can be set via `ZOOKEEPER_SUPERUSER_PASSWORD` environment variable.",
+			},
 			"tls_enabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -94,57 +331,301 @@ func New() (*schema.Provider, error) {
 				Description: "File path to a client key to use when connecting to the ZooKeeper " +
 					"server(s) using TLS. Can be set via `ZOOKEEPER_TLS_KEY_FILE` environment variable.",
 			},
+			"tls_key_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperTLSKeyPassword, nil),
+				Description: "Password to decrypt the client key, if encrypted (PKCS#8 or legacy OpenSSL PEM " +
+					"encryption). When using a keystore, this is the password of the key entry, " +
+					"if different from `tls_keystore_password`. " +
+					"Can be set via `ZOOKEEPER_TLS_KEY_PASSWORD` environment variable.",
+			},
+			"tls_keystore_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     false,
+				DefaultFunc:   schema.EnvDefaultFunc(client.EnvZooKeeperTLSKeyStoreFile, nil),
+				ConflictsWith: []string{"tls_cert_file", "tls_key_file"},
+				Description: "File path to a keystore containing the client certificate and key to use " +
+					"when connecting to the ZooKeeper server(s) using TLS. " +
+					"Equivalent to the Java client `zookeeper.ssl.keyStore.location` setting. " +
+					"Can be set via `ZOOKEEPER_TLS_KEYSTORE_FILE` environment variable.",
+			},
+			"tls_keystore_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperTLSKeyStorePassword, nil),
+				Description: "Password of the keystore set via `tls_keystore_file`. " +
+					"Can be set via `ZOOKEEPER_TLS_KEYSTORE_PASSWORD` environment variable.",
+			},
+			"tls_keystore_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    false,
+				DefaultFunc:  schema.EnvDefaultFunc(client.EnvZooKeeperTLSKeyStoreType, nil),
+				ValidateFunc: validation.StringInSlice(client.SupportedStoreTypes(), true),
+				Description: "Type of the keystore set via `tls_keystore_file`: one of `JKS`, `PKCS12` or `PEM`. " +
+					"If not set, it is detected from the file extension. " +
+					"Can be set via `ZOOKEEPER_TLS_KEYSTORE_TYPE` environment variable.",
+			},
+			"tls_truststore_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     false,
+				DefaultFunc:   schema.EnvDefaultFunc(client.EnvZooKeeperTLSTrustStoreFile, nil),
+				ConflictsWith: []string{"tls_ca_file"},
+				Description: "File path to a truststore containing the root CA certificate(s) to use " +
+					"when connecting to the ZooKeeper server(s) using TLS. " +
+					"Equivalent to the Java client `zookeeper.ssl.trustStore.location` setting. " +
+					"Can be set via `ZOOKEEPER_TLS_TRUSTSTORE_FILE` environment variable.",
+			},
+			"tls_truststore_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperTLSTrustStorePassword, nil),
+				Description: "Password of the truststore set via `tls_truststore_file`. " +
+					"Can be set via `ZOOKEEPER_TLS_TRUSTSTORE_PASSWORD` environment variable.",
+			},
+			"tls_truststore_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    false,
+				DefaultFunc:  schema.EnvDefaultFunc(client.EnvZooKeeperTLSTrustStoreType, nil),
+				ValidateFunc: validation.StringInSlice(client.SupportedStoreTypes(), true),
+				Description: "Type of the truststore set via `tls_truststore_file`: one of `JKS`, `PKCS12` or `PEM`. " +
+					"If not set, it is detected from the file extension. " +
+					"Can be set via `ZOOKEEPER_TLS_TRUSTSTORE_TYPE` environment variable.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"zookeeper_znode":            resourceZNode(),
-			"zookeeper_sequential_znode": resourceSeqZNode(),
+			"zookeeper_znode":               resourceZNode(),
+			"zookeeper_sequential_znode":    resourceSeqZNode(),
+			"zookeeper_ensemble_membership": resourceEnsembleMembership(),
+			"zookeeper_quota":               resourceQuota(),
+			"zookeeper_znode_copy":          resourceZNodeCopy(),
+			"zookeeper_znode_wait":          resourceZNodeWait(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"zookeeper_znode": datasourceZNode(),
+			"zookeeper_znode":           datasourceZNode(),
+			"zookeeper_server_status":   datasourceServerStatus(),
+			"zookeeper_ensemble_config": datasourceEnsembleConfig(),
+			"zookeeper_subtree_stats":   datasourceSubtreeStats(),
 		},
 		ConfigureContextFunc: func(_ context.Context, rscData *schema.ResourceData) (interface{}, diag.Diagnostics) {
-			// Retrieve the given configuration
-			servers := rscData.Get("servers").(string)
-			sessionTimeout := rscData.Get("session_timeout").(int)
-			username := rscData.Get("username").(string)
-			password := rscData.Get("password").(string)
-
-			tlsConfig, err := client.NewTLSConfig(
-				rscData.Get("tls_enabled").(bool),
-				rscData.Get("tls_skip_verify").(bool),
-				rscData.Get("tls_ca_file").(string),
-				rscData.Get("tls_cert_file").(string),
-				rscData.Get("tls_key_file").(string),
-			)
+			// Retrieve the given configuration, layered on top of
+			// the client configuration file and the connection profile (if any)
+			cfg, err := client.ResolveConfig(&client.ConfigSources{
+				ClientConfigFile: rscData.Get("client_config_file").(string),
+				ProfilesFile:     rscData.Get("profiles_file").(string),
+				Profile:          rscData.Get("profile").(string),
+			}, configFromResourceData(rscData))
 			if err != nil {
-				// Report invalid TLS configuration
-				return nil, diag.Errorf("Unable to parse TLS config: %v", err)
+				// Report invalid configuration sources
+				return nil, diag.Errorf("Unable to load provider configuration: %v", err)
 			}
 
-			if servers != "" {
+			if cfg.Servers != "" || cfg.ServersSRV != "" {
 				// NOTE: Client Pool above is in a closure here
 				// because we don't have a way to add fields to the Provider.
-				c, err := clientPool.GetOrCreateClient( //nolint:contextcheck
-					servers,
-					sessionTimeout,
-					username,
-					password,
-					tlsConfig,
-				)
+				c, err := clientPool.GetOrCreateClient(cfg) //nolint:contextcheck
 				if err != nil {
 					// Report inability to connect internal Client
 					return nil, diag.Errorf(
 						"Unable creating ZooKeeper client against '%s': %v",
-						servers,
+						cfg.Servers+cfg.ServersSRV,
 						err,
 					)
 				}
 
+				if cfg.HealthCheck {
+					return c, checkHealth(c, &cfg.HealthThresholds)
+				}
+
 				return c, diag.Diagnostics{}
 			}
 
 			// Report missing mandatory arguments
-			return nil, diag.Errorf("Provider requires at least the '%s' argument", "servers")
+			return nil, diag.Errorf(
+				"Provider requires at least the '%s' argument (or '%s', '%s', or a '%s' or '%s' providing it)",
+				"servers",
+				"server_list",
+				"servers_srv",
+				"client_config_file",
+				"profile",
+			)
 		},
 	}, nil
 }
+
+// configFromResourceData retrieves the *client.Config set on the provider.
+//
+// Attributes that are not set are left to their zero-value, and boolean and integer attributes that are set
+// are marked as explicit (see client.Config.ExplicitSettings), so that the result can be layered on top of
+// other configuration sources via client.Config.Merge.
+func configFromResourceData(rscData *schema.ResourceData) *client.Config {
+	servers := rscData.Get("servers").(string)
+	if serverList := rscData.Get("server_list").([]interface{}); len(serverList) > 0 {
+		servers = ""
+		for i, server := range serverList {
+			if i > 0 {
+				servers += ","
+			}
+			servers += server.(string)
+		}
+	}
+
+	return &client.Config{
+		Servers:           servers,
+		ServersSRV:        rscData.Get("servers_srv").(string),
+		SessionTimeoutSec: rscData.Get("session_timeout").(int),
+		Username:          rscData.Get("username").(string),
+		Password:          rscData.Get("password").(string),
+		Chroot:            rscData.Get("chroot").(string),
+		ProxyURL:          rscData.Get("proxy_url").(string),
+		AdminServerURL:    rscData.Get("admin_server_url").(string),
+		SuperuserUsername: rscData.Get("superuser_username").(string),
+		SuperuserPassword: rscData.Get("superuser_password").(string),
+		TLS: client.TLSOptions{
+			IsEnabled:          rscData.Get("tls_enabled").(bool),
+			SkipVerify:         rscData.Get("tls_skip_verify").(bool),
+			CAFile:             rscData.Get("tls_ca_file").(string),
+			CertFile:           rscData.Get("tls_cert_file").(string),
+			KeyFile:            rscData.Get("tls_key_file").(string),
+			KeyPassword:        rscData.Get("tls_key_password").(string),
+			KeyStoreFile:       rscData.Get("tls_keystore_file").(string),
+			KeyStorePassword:   rscData.Get("tls_keystore_password").(string),
+			KeyStoreType:       rscData.Get("tls_keystore_type").(string),
+			TrustStoreFile:     rscData.Get("tls_truststore_file").(string),
+			TrustStorePassword: rscData.Get("tls_truststore_password").(string),
+			TrustStoreType:     rscData.Get("tls_truststore_type").(string),
+		},
+		MaxBufferSize:         rscData.Get("max_buffer_size").(int),
+		ConnectTimeoutSec:     rscData.Get("connect_timeout").(int),
+		MaxReconnectAttempts:  rscData.Get("max_reconnect_attempts").(int),
+		ServerOrder:           rscData.Get("server_order").(string),
+		SyncReads:             rscData.Get("sync_reads").(bool),
+		AllowReadOnly:         rscData.Get("allow_read_only").(bool),
+		WaitForPropagation:    rscData.Get("wait_for_propagation").(bool),
+		PropagationTimeoutSec: rscData.Get("propagation_timeout").(int),
+		HealthCheck:           rscData.Get("health_check").(bool),
+		HealthThresholds: client.HealthThresholds{
+			MinQuorumSize:          rscData.Get("health_check_min_quorum").(int),
+			MaxOutstandingRequests: rscData.Get("health_check_max_outstanding_requests").(int),
+		},
+		ExplicitSettings: explicitSettings(rscData),
+	}
+}
+
+// explicitSettings returns the boolean and integer settings set on the provider,
+// either in its configuration (even if to their zero-value), or via their environment variable.
+func explicitSettings(rscData *schema.ResourceData) client.Setting {
+	rawConfig := rscData.GetRawConfig()
+
+	var explicit client.Setting
+	for attr, attrSetting := range map[string]struct {
+		setting client.Setting
+		envVar  string
+	}{
+		"session_timeout": {client.SettingSessionTimeout, client.EnvZooKeeperSessionSec},
+		"max_buffer_size": {client.SettingMaxBufferSize, client.EnvZooKeeperMaxBufferSize},
+		"connect_timeout": {client.SettingConnectTimeout, client.EnvZooKeeperConnectTimeoutSec},
+		"max_reconnect_attempts": {
+			client.SettingMaxReconnectAttempts,
+			client.EnvZooKeeperMaxReconnectAttempts,
+		},
+		"sync_reads":      {client.SettingSyncReads, client.EnvZooKeeperSyncReads},
+		"allow_read_only": {client.SettingAllowReadOnly, client.EnvZooKeeperAllowReadOnly},
+		"wait_for_propagation": {
+			client.SettingWaitForPropagation,
+			client.EnvZooKeeperWaitForPropagation,
+		},
+		"propagation_timeout": {
+			client.SettingPropagationTimeout,
+			client.EnvZooKeeperPropagationTimeoutSec,
+		},
+		"health_check": {client.SettingHealthCheck, client.EnvZooKeeperHealthCheck},
+		"health_check_min_quorum": {
+			client.SettingHealthCheckMinQuorum,
+			client.EnvZooKeeperHealthCheckMinQuorum,
+		},
+		"health_check_max_outstanding_requests": {
+			client.SettingHealthCheckMaxOutstandingRequests,
+			client.EnvZooKeeperHealthCheckMaxOutstandingRequests,
+		},
+		"tls_enabled":     {client.SettingTLSEnabled, client.EnvZooKeeperTLSEnabled},
+		"tls_skip_verify": {client.SettingTLSSkipVerify, client.EnvZooKeeperTLSSkipVerify},
+	} {
+		// NOTE: Attributes are set via their environment variable when not set in the configuration
+		if (!rawConfig.IsNull() && !rawConfig.GetAttr(attr).IsNull()) ||
+			os.Getenv(attrSetting.envVar) != "" {
+			explicit |= attrSetting.setting
+		}
+	}
+
+	return explicit
+}
+
+// checkHealth checks the health of the ensemble (see client.Client.CheckHealth).
+//
+// Unhealthy servers are reported as warnings, while each failed check is reported as an error.
+func checkHealth(zkClient *client.Client, thresholds *client.HealthThresholds) diag.Diagnostics {
+	health, violations := zkClient.CheckHealth(thresholds)
+
+	diags := diag.Diagnostics{}
+	for _, h := range health {
+		if h.Error != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("ZooKeeper server '%s' is unhealthy", h.Server),
+				Detail:   h.Error.Error(),
+			})
+		}
+	}
+	for _, violation := range violations {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "ZooKeeper ensemble is degraded",
+			Detail:   violation.Error(),
+		})
+	}
+
+	return diags
+}
+
+// validateServer is a schema.SchemaValidateFunc for a single ZooKeeper server entry (see client.NormalizeServer).
+func validateServer(i interface{}, k string) ([]string, []error) {
+	server, _ := i.(string)
+	if _, err := client.NormalizeServer(server); err != nil {
+		return nil, []error{fmt.Errorf("%q: %w", k, err)}
+	}
+
+	return nil, nil
+}
+
+// validateAdminServerURL is a schema.SchemaValidateFunc for the AdminServer URL (see client.ValidateAdminServerURL).
+func validateAdminServerURL(i interface{}, k string) ([]string, []error) {
+	adminServerURL, _ := i.(string)
+	if err := client.ValidateAdminServerURL(adminServerURL); err != nil {
+		return nil, []error{fmt.Errorf("%q: %w", k, err)}
+	}
+
+	return nil, nil
+}
+
+// validateConnectString is a schema.SchemaValidateFunc for a ZooKeeper connect string
+// (see client.ParseConnectString).
+func validateConnectString(i interface{}, k string) ([]string, []error) {
+	connectString, _ := i.(string)
+	servers, _ := client.ParseConnectString(connectString)
+
+	var errs []error
+	for _, server := range servers {
+		_, serverErrs := validateServer(server, k)
+		errs = append(errs, serverErrs...)
+	}
+
+	return nil, errs
+}
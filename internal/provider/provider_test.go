@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	testifyAssert "github.com/stretchr/testify/assert"
@@ -21,6 +22,34 @@ func TestProvider(t *testing.T) {
 	assert.NoError(p.InternalValidate())
 }
 
+func TestProviderServersValidation(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	p, err := provider.New()
+	assert.NoError(err)
+
+	diags := p.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"server_list": []interface{}{"zk-01:2181", "[::1]:2181", "zk-03"},
+	}))
+	assert.False(diags.HasError())
+
+	diags = p.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"server_list": []interface{}{"zk-01:2181", "::1:2181"},
+	}))
+	assert.True(diags.HasError())
+
+	diags = p.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"servers": "zk-01:2181,zk-02:99999/app",
+	}))
+	assert.True(diags.HasError())
+
+	diags = p.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"servers":     "zk-01:2181",
+		"servers_srv": "_zookeeper._tcp.example.com",
+	}))
+	assert.True(diags.HasError())
+}
+
 //nolint:unparam
 func providerFactoriesMap() map[string]func() (*schema.Provider, error) {
 	// Instantiate the provider in advance...
@@ -49,6 +78,29 @@ func checkPreconditions(t *testing.T) {
 	}
 }
 
+// ensembleMembers returns the members of the ZooKeeper ensemble the acceptance tests run against,
+// skipping the test if it's not an ensemble (ex. a standalone server, like in CI).
+func ensembleMembers(t *testing.T) []*client.EnsembleMember {
+	t.Helper()
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	checkPreconditions(t)
+
+	zkClient, err := client.NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("failed to create new Client: %v", err)
+	}
+	defer zkClient.Close()
+
+	ensembleCfg, err := zkClient.EnsembleConfig()
+	if err != nil || len(ensembleCfg.Members) == 0 {
+		t.Skip("Acceptance test requires a ZooKeeper 3.5+ ensemble (see `make local.zk.up`)")
+	}
+
+	return ensembleCfg.Members
+}
+
 // confirmAllZNodeDestroyed should be used with the field `CheckDestroy` of resource.TestCase.
 //
 //nolint:err113
@@ -61,7 +113,8 @@ func confirmAllZNodeDestroyed(s *terraform.State) error {
 	defer zkClient.Close()
 
 	for _, rs := range s.RootModule().Resources {
-		if rs.Type != "zookeeper_znode" && rs.Type != "zookeeper_sequential_znode" {
+		if rs.Type != "zookeeper_znode" && rs.Type != "zookeeper_sequential_znode" &&
+			rs.Type != "zookeeper_znode_copy" {
 			continue
 		}
 
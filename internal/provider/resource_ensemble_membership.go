@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+// ErrEnsembleMembershipImport is returned when importing the ensemble membership fails.
+var ErrEnsembleMembershipImport = errors.New("failed to import ensemble membership")
+
+func resourceEnsembleMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEnsembleMembershipCreate,
+		ReadContext:   resourceEnsembleMembershipRead,
+		UpdateContext: resourceEnsembleMembershipUpdate,
+		DeleteContext: resourceEnsembleMembershipDelete,
+		CustomizeDiff: resourceEnsembleMembershipCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceEnsembleMembershipImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"member": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "Members of the ensemble.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "The server ID of the member (i.e. its `myid`).",
+						},
+						"host": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The host of the member.",
+						},
+						"quorum_port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      2888,
+							ValidateFunc: validation.IsPortNumber,
+							Description:  "The port the member uses to communicate with the leader.",
+						},
+						"election_port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3888,
+							ValidateFunc: validation.IsPortNumber,
+							Description:  "The port the member uses for leader election.",
+						},
+						"role": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  client.MemberRoleParticipant,
+							ValidateFunc: validation.StringInSlice(
+								[]string{client.MemberRoleParticipant, client.MemberRoleObserver},
+								false,
+							),
+							Description: "The role of the member: either 'participant' or 'observer'.",
+						},
+						"client_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+							Description: "The address the member serves clients on. " +
+								"If not set, it serves them on all its addresses.",
+						},
+						"client_port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      2181,
+							ValidateFunc: validation.IsPortNumberOrZero,
+							Description:  "The port the member serves clients on. Set to `0` if it doesn't serve clients.",
+						},
+					},
+				},
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Version of the configuration, in hex format " +
+					"(i.e. the zxid of the reconfiguration that created it).",
+			},
+		},
+		Description: "Manages the members of the ZooKeeper ensemble, via " + dynamicReconfigLinkForDesc + ". " +
+			"Changes are applied as incremental reconfigurations, conditional on the version " +
+			"of the configuration read during the plan, and refused if they would lose quorum. " +
+			"Requires ZooKeeper 3.5 or later, with `reconfigEnabled=true`, and (usually) the provider " +
+			"to be configured with the ZooKeeper superuser credentials (see `superuser_password`). " +
+			"Destroying this resource doesn't change the ensemble: it's only removed from the Terraform state.",
+	}
+}
+
+func resourceEnsembleMembershipCreate(
+	ctx context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	current, err := zkClient.EnsembleConfig()
+	if err != nil {
+		return diag.Errorf("Unable to read ensemble dynamic configuration: %v", err)
+	}
+
+	diags := reconfigEnsemble(zkClient, rscData, current.Members, current.Version)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Terraform will use the path of the dynamic configuration as unique identifier for this Resource
+	rscData.SetId(client.EnsembleConfigPath)
+	rscData.MarkNewResource()
+
+	return append(diags, resourceEnsembleMembershipRead(ctx, rscData, prvClient)...)
+}
+
+func resourceEnsembleMembershipRead(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	ensembleCfg, err := zkClient.EnsembleConfig()
+	if err != nil {
+		return diag.Errorf("Unable to read ensemble dynamic configuration: %v", err)
+	}
+
+	members := make([]map[string]interface{}, 0, len(ensembleCfg.Members))
+	for _, member := range ensembleCfg.Members {
+		members = append(members, map[string]interface{}{
+			"id":             int(member.ID),
+			"host":           member.Host,
+			"quorum_port":    member.QuorumPort,
+			"election_port":  member.ElectionPort,
+			"role":           member.Role,
+			"client_address": member.ClientAddress,
+			"client_port":    member.ClientPort,
+		})
+	}
+
+	diags := diag.Diagnostics{}
+	if err := rscData.Set("member", members); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+	if err := rscData.Set("version", strconv.FormatInt(ensembleCfg.Version, 16)); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+func resourceEnsembleMembershipUpdate(
+	ctx context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	if rscData.HasChange("member") {
+		// NOTE: The change was planned against the configuration version in the state:
+		// the reconfiguration fails if the ensemble has been reconfigured since
+		fromVersion, err := strconv.ParseInt(rscData.Get("version").(string), 16, 64)
+		if err != nil {
+			return diag.Errorf("Invalid ensemble configuration version in state: %v", err)
+		}
+
+		oldMembers, _ := rscData.GetChange("member")
+		diags := reconfigEnsemble(
+			zkClient,
+			rscData,
+			membersFromSet(oldMembers.(*schema.Set)),
+			fromVersion,
+		)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceEnsembleMembershipRead(ctx, rscData, prvClient)
+}
+
+func resourceEnsembleMembershipDelete(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	_ interface{},
+) diag.Diagnostics {
+	rscData.SetId("")
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Ensemble membership removed from state only",
+			Detail: "The members of the ZooKeeper ensemble have not been changed: " +
+				"removing all of them is not possible.",
+		},
+	}
+}
+
+func resourceEnsembleMembershipImport(
+	ctx context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) ([]*schema.ResourceData, error) {
+	// NOTE: There is only one ensemble, whatever the ID given
+	rscData.SetId(client.EnsembleConfigPath)
+
+	if diags := resourceEnsembleMembershipRead(ctx, rscData, prvClient); diags.HasError() {
+		return nil, fmt.Errorf("%w: %s", ErrEnsembleMembershipImport, diags[0].Summary)
+	}
+
+	return []*schema.ResourceData{rscData}, nil
+}
+
+// resourceEnsembleMembershipCustomizeDiff refuses, at plan time, changes to the members that would lose quorum.
+func resourceEnsembleMembershipCustomizeDiff(
+	_ context.Context,
+	diff *schema.ResourceDiff,
+	_ interface{},
+) error {
+	if diff.Id() == "" || !diff.HasChange("member") {
+		return nil
+	}
+
+	oldMembers, newMembers := diff.GetChange("member")
+
+	return client.CheckReconfigQuorum( //nolint:wrapcheck
+		membersFromSet(oldMembers.(*schema.Set)),
+		membersFromSet(newMembers.(*schema.Set)),
+	)
+}
+
+// reconfigEnsemble changes the members of the ensemble, from current to the ones set in the *schema.ResourceData.
+func reconfigEnsemble(
+	zkClient *client.Client,
+	rscData *schema.ResourceData,
+	current []*client.EnsembleMember,
+	fromVersion int64,
+) diag.Diagnostics {
+	desired := membersFromSet(rscData.Get("member").(*schema.Set))
+
+	if err := client.CheckReconfigQuorum(current, desired); err != nil {
+		return diag.Errorf("Refusing to reconfigure the ensemble: %v", err)
+	}
+
+	joining, leaving := client.DiffEnsembleMembers(current, desired)
+	if len(joining) == 0 && len(leaving) == 0 {
+		return diag.Diagnostics{}
+	}
+
+	if _, err := zkClient.IncrementalReconfig(joining, leaving, fromVersion); err != nil {
+		return diag.Errorf("Failed to reconfigure the ensemble: %v", err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+// membersFromSet converts the `member` attribute into []*client.EnsembleMember.
+func membersFromSet(set *schema.Set) []*client.EnsembleMember {
+	members := make([]*client.EnsembleMember, 0, set.Len())
+	for _, raw := range set.List() {
+		member := raw.(map[string]interface{})
+		members = append(members, &client.EnsembleMember{
+			ID:            int64(member["id"].(int)),
+			Host:          member["host"].(string),
+			QuorumPort:    member["quorum_port"].(int),
+			ElectionPort:  member["election_port"].(int),
+			Role:          member["role"].(string),
+			ClientAddress: member["client_address"].(string),
+			ClientPort:    member["client_port"].(int),
+		})
+	}
+
+	return members
+}
@@ -0,0 +1,84 @@
+package provider_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func TestAccResourceEnsembleMembership(t *testing.T) {
+	members := ensembleMembers(t)
+
+	// NOTE: The ensemble is not changed, as the configuration declares its current members.
+	// Wildcard client addresses (ex. `0.0.0.0`, as in the local ensemble) are left unset.
+	var config strings.Builder
+	config.WriteString(`resource "zookeeper_ensemble_membership" "ensemble" {`)
+	for _, member := range members {
+		clientAddress := ""
+		if member.ClientAddress != "" {
+			clientAddress = fmt.Sprintf("client_address = %q", member.ClientAddress)
+		}
+		fmt.Fprintf(&config, `
+			member {
+				id            = %d
+				host          = %q
+				quorum_port   = %d
+				election_port = %d
+				role          = %q
+				client_port   = %d
+				%s
+			}`,
+			member.ID,
+			member.Host,
+			member.QuorumPort,
+			member.ElectionPort,
+			member.Role,
+			member.ClientPort,
+			clientAddress,
+		)
+	}
+	config.WriteString("\n}")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: config.String(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"zookeeper_ensemble_membership.ensemble",
+						"id",
+						client.EnsembleConfigPath,
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_ensemble_membership.ensemble",
+						"member.#",
+						fmt.Sprint(len(members)),
+					),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"zookeeper_ensemble_membership.ensemble",
+						"member.*",
+						map[string]string{
+							"id":             fmt.Sprint(members[0].ID),
+							"host":           members[0].Host,
+							"client_address": members[0].ClientAddress,
+						},
+					),
+					resource.TestCheckResourceAttrSet(
+						"zookeeper_ensemble_membership.ensemble",
+						"version",
+					),
+				),
+			},
+			{
+				ResourceName:      "zookeeper_ensemble_membership.ensemble",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+const (
+	quotaLinkForDesc = "[ZooKeeper quota](https://zookeeper.apache.org/doc/current/zookeeperQuotas.html)"
+)
+
+func resourceQuota() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceQuotaCreate,
+		ReadContext:   resourceQuotaRead,
+		UpdateContext: resourceQuotaUpdate,
+		DeleteContext: resourceQuotaDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Absolute path to the ZNode to set the quota of. The ZNode must exist.",
+			},
+			"count_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(client.QuotaUnlimited),
+				ValidateFunc: validation.IntAtLeast(int(client.QuotaUnlimited)),
+				Description: "Soft limit on the number of ZNodes in the subtree (including the ZNode itself): " +
+					"ZooKeeper logs a warning when exceeded. Defaults to `-1` (unlimited).",
+			},
+			"bytes_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(client.QuotaUnlimited),
+				ValidateFunc: validation.IntAtLeast(int(client.QuotaUnlimited)),
+				Description: "Soft limit on the bytes of data stored in the subtree: " +
+					"ZooKeeper logs a warning when exceeded. Defaults to `-1` (unlimited).",
+			},
+			"count_hard_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(client.QuotaUnlimited),
+				ValidateFunc: validation.IntAtLeast(int(client.QuotaUnlimited)),
+				Description: "Hard limit on the number of ZNodes in the subtree (including the ZNode itself): " +
+					"ZooKeeper refuses the writes that would exceed it. Requires ZooKeeper 3.7 or later, " +
+					"with `zookeeper.enforceQuota=true`. Defaults to `-1` (unlimited).",
+			},
+			"bytes_hard_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(client.QuotaUnlimited),
+				ValidateFunc: validation.IntAtLeast(int(client.QuotaUnlimited)),
+				Description: "Hard limit on the bytes of data stored in the subtree: " +
+					"ZooKeeper refuses the writes that would exceed it. Requires ZooKeeper 3.7 or later, " +
+					"with `zookeeper.enforceQuota=true`. Defaults to `-1` (unlimited).",
+			},
+			"usage_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Current number of ZNodes in the subtree, as tracked by ZooKeeper.",
+			},
+			"usage_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Current bytes of data stored in the subtree, as tracked by ZooKeeper.",
+			},
+		},
+		Description: "Manages the " + quotaLinkForDesc + " of a " + zNodeLinkForDesc + ": " +
+			"limits on the number of ZNodes, and on the bytes of data, of its subtree. " +
+			"Quotas cannot be nested: setting a quota on a ZNode with an ancestor, " +
+			"or a descendant, that has a quota set fails.",
+	}
+}
+
+func resourceQuotaCreate(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	znodePath := rscData.Get("path").(string)
+
+	quota, err := zkClient.SetQuota(znodePath, quotaLimitsFromResourceData(rscData))
+	if err != nil {
+		return diag.Errorf("Failed to set quota of ZNode '%s': %v", znodePath, err)
+	}
+
+	// Terraform will use the ZNode.Path as unique identifier for this Resource
+	rscData.SetId(quota.Path)
+	rscData.MarkNewResource()
+
+	return setAttributesFromQuota(rscData, quota)
+}
+
+func resourceQuotaRead(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	znodePath := rscData.Id()
+
+	quota, err := zkClient.ReadQuota(znodePath)
+	if err != nil {
+		// If the quota is not found, it means it was removed outside of Terraform.
+		// We set the ID to blank, so it's state will be removed.
+		if errors.Is(err, client.ErrQuotaNotFound) {
+			rscData.SetId("")
+			return diag.Diagnostics{}
+		}
+
+		return diag.Errorf("Failed to read quota of ZNode '%s': %v", znodePath, err)
+	}
+
+	return setAttributesFromQuota(rscData, quota)
+}
+
+func resourceQuotaUpdate(
+	ctx context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	znodePath := rscData.Id()
+
+	if rscData.HasChanges("count_limit", "bytes_limit", "count_hard_limit", "bytes_hard_limit") {
+		quota, err := zkClient.SetQuota(znodePath, quotaLimitsFromResourceData(rscData))
+		if err != nil {
+			return diag.Errorf("Failed to update quota of ZNode '%s': %v", znodePath, err)
+		}
+
+		return setAttributesFromQuota(rscData, quota)
+	}
+
+	return resourceQuotaRead(ctx, rscData, prvClient)
+}
+
+func resourceQuotaDelete(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	znodePath := rscData.Id()
+
+	if err := zkClient.DeleteQuota(znodePath); err != nil {
+		return diag.Errorf("Failed to delete quota of ZNode '%s': %v", znodePath, err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+// quotaLimitsFromResourceData reads the limits set in the *schema.ResourceData.
+func quotaLimitsFromResourceData(rscData *schema.ResourceData) *client.QuotaLimits {
+	return &client.QuotaLimits{
+		Count:          int64(rscData.Get("count_limit").(int)),
+		Bytes:          int64(rscData.Get("bytes_limit").(int)),
+		CountHardLimit: int64(rscData.Get("count_hard_limit").(int)),
+		BytesHardLimit: int64(rscData.Get("bytes_hard_limit").(int)),
+	}
+}
+
+// setAttributesFromQuota takes a *client.Quota and populates the *schema.ResourceData with its content.
+func setAttributesFromQuota(rscData *schema.ResourceData, quota *client.Quota) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	for attr, value := range map[string]int64{
+		"count_limit":      quota.Limits.Count,
+		"bytes_limit":      quota.Limits.Bytes,
+		"count_hard_limit": quota.Limits.CountHardLimit,
+		"bytes_hard_limit": quota.Limits.BytesHardLimit,
+		"usage_count":      quota.Count,
+		"usage_bytes":      quota.Bytes,
+	} {
+		if err := rscData.Set(attr, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	if err := rscData.Set("path", quota.Path); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
@@ -0,0 +1,71 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceQuota(t *testing.T) {
+	path := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:      confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "app" {
+						path = "%s"
+						data = "data"
+					}
+					resource "zookeeper_znode" "child" {
+						path = "${zookeeper_znode.app.path}/child"
+						data = "child data"
+					}
+					resource "zookeeper_quota" "app" {
+						path        = zookeeper_znode.app.path
+						count_limit = 10
+
+						depends_on = [zookeeper_znode.child]
+					}`, path,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_quota.app", "id", path),
+					resource.TestCheckResourceAttr("zookeeper_quota.app", "count_limit", "10"),
+					resource.TestCheckResourceAttr("zookeeper_quota.app", "bytes_limit", "-1"),
+					resource.TestCheckResourceAttr("zookeeper_quota.app", "count_hard_limit", "-1"),
+					resource.TestCheckResourceAttr("zookeeper_quota.app", "bytes_hard_limit", "-1"),
+					resource.TestCheckResourceAttr("zookeeper_quota.app", "usage_count", "2"),
+					resource.TestCheckResourceAttr("zookeeper_quota.app", "usage_bytes", "14"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "app" {
+						path = "%s"
+						data = "data"
+					}
+					resource "zookeeper_quota" "app" {
+						path        = zookeeper_znode.app.path
+						count_limit = 10
+						bytes_limit = 1024
+					}`, path,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_quota.app", "count_limit", "10"),
+					resource.TestCheckResourceAttr("zookeeper_quota.app", "bytes_limit", "1024"),
+				),
+			},
+			{
+				ResourceName:            "zookeeper_quota.app",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"usage_count", "usage_bytes"},
+			},
+		},
+	})
+}
@@ -15,10 +15,11 @@ func resourceSeqZNode() *schema.Resource {
 		ReadContext:   resourceSeqZNodeRead,
 		UpdateContext: resourceSeqZNodeUpdate,
 		DeleteContext: resourceSeqZNodeDelete,
+		CustomizeDiff: customizeDiffDestroyImpact("path_prefix"),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSeqZNodeImport,
 		},
-		Schema: map[string]*schema.Schema{
+		Schema: withDeletePolicySchemas(map[string]*schema.Schema{
 			"path_prefix": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -81,7 +82,7 @@ func resourceSeqZNode() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 		Description: "Manages the lifecycle of a " +
 			zNodeLinkForDesc + ". " +
 			"This resource manages **Persistent Sequential ZNodes**. " +
@@ -118,15 +119,19 @@ func resourceSeqZNodeCreate(
 	rscData.SetId(znode.Path)
 	rscData.MarkNewResource()
 
-	return setAttributesFromZNode(rscData, znode, diag.Diagnostics{})
+	diags := setAttributesFromZNode(rscData, znode, waitForPropagation(zkClient, znode))
+	diags = setCreatedParents(rscData, znode, diags)
+
+	return setDestroyImpact(zkClient, rscData, diags)
 }
 
 func resourceSeqZNodeRead(
-	ctx context.Context,
+	_ context.Context,
 	rscData *schema.ResourceData,
 	prvClient interface{},
 ) diag.Diagnostics {
-	return resourceZNodeRead(ctx, rscData, prvClient)
+	// NOTE: Not resourceZNodeRead, as the attributes specific to `zookeeper_znode` are not in the schema
+	return readZNode(prvClient.(*client.Client), rscData)
 }
 
 func resourceSeqZNodeUpdate(
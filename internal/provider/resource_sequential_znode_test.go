@@ -49,9 +49,29 @@ func TestAccResourceSeqZNode_FromDir(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      "zookeeper_sequential_znode.from_dir",
-				ImportState:       true,
-				ImportStateVerify: true,
+				// Refresh, as plans do, without the attributes specific to `zookeeper_znode`
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"zookeeper_sequential_znode.from_dir",
+						"data",
+						"sequential znode created by passing a dir",
+					),
+					resource.TestCheckNoResourceAttr(
+						"zookeeper_sequential_znode.from_dir",
+						"adopt_existing",
+					),
+					resource.TestCheckNoResourceAttr(
+						"zookeeper_sequential_znode.from_dir",
+						"move_on_path_change",
+					),
+				),
+			},
+			{
+				ResourceName:            "zookeeper_sequential_znode.from_dir",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_parents"},
 			},
 		},
 	})
@@ -97,9 +117,10 @@ func TestAccResourceSeqZNode_FromPrefix(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      "zookeeper_sequential_znode.from_prefix",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "zookeeper_sequential_znode.from_prefix",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_parents"},
 			},
 		},
 	})
@@ -165,9 +186,10 @@ func TestAccResourceSeqZNode_DefaultACL(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      "zookeeper_sequential_znode.default_acl",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "zookeeper_sequential_znode.default_acl",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_parents"},
 			},
 		},
 	})
@@ -238,9 +260,10 @@ func TestAccResourceSeqZNode_WithACL(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      "zookeeper_sequential_znode.with_acl",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "zookeeper_sequential_znode.with_acl",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_parents"},
 			},
 		},
 	})
@@ -3,27 +3,70 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/go-zookeeper/zk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
 )
 
+// Policies to handle a ZNode that already exists, when creating the resource that manages it.
+const (
+	adoptExistingFail      = "fail"
+	adoptExistingAdopt     = "adopt"
+	adoptExistingOverwrite = "overwrite"
+)
+
 func resourceZNode() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceZNodeCreate,
 		ReadContext:   resourceZNodeRead,
 		UpdateContext: resourceZNodeUpdate,
 		DeleteContext: resourceZNodeDelete,
+		CustomizeDiff: resourceZNodeCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: map[string]*schema.Schema{
+		Schema: withDeletePolicySchemas(map[string]*schema.Schema{
 			"path": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Absolute path to the ZNode to create.",
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "Absolute path to the ZNode to create. " +
+					"Changing it replaces the ZNode (deleting its descendants), unless `move_on_path_change` is set.",
+			},
+			"move_on_path_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Move the ZNode when `path` changes, instead of replacing it: " +
+					"data and ACL (and descendants, see `move_descendants`) are moved to the new path, " +
+					"atomically if the subtree is small enough to be moved with a single request. " +
+					"Fails if the subtree contains ephemeral ZNodes. Defaults to `false`.",
+			},
+			"move_descendants": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Move the descendants of the ZNode too, when moving it (see `move_on_path_change`). " +
+					"If `false`, moving a ZNode that has children fails. Defaults to `true`.",
+			},
+			"adopt_existing": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  adoptExistingFail,
+				ValidateFunc: validation.StringInSlice(
+					[]string{adoptExistingFail, adoptExistingAdopt, adoptExistingOverwrite},
+					false,
+				),
+				Description: "What to do if the ZNode already exists, when the resource is created: " +
+					"`fail` refuses to create the resource; " +
+					"`adopt` takes ownership of the existing ZNode, setting `data` and `acl` only if configured " +
+					"(and keeping the existing ones otherwise); " +
+					"`overwrite` takes ownership of the existing ZNode, replacing its data and ACL with the configured " +
+					"ones (i.e. empty data and `world:anyone` ACL, if not configured). " +
+					"Descendants of an existing ZNode are left untouched. Defaults to `fail`.",
 			},
 			"data": {
 				Type:          schema.TypeString,
@@ -70,7 +113,7 @@ func resourceZNode() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 		Description: "Manages the lifecycle of a " +
 			zNodeLinkForDesc + ". " +
 			"This resource manages **Persistent ZNodes**. " +
@@ -79,6 +122,34 @@ func resourceZNode() *schema.Resource {
 	}
 }
 
+// resourceZNodeCustomizeDiff replaces the ZNode when `path` changes, unless it's set to be moved instead.
+func resourceZNodeCustomizeDiff(
+	ctx context.Context,
+	diff *schema.ResourceDiff,
+	prvClient interface{},
+) error {
+	if diff.Id() == "" || !diff.HasChange("path") {
+		return customizeDiffDestroyImpact("path")(ctx, diff, prvClient)
+	}
+
+	if !diff.Get("move_on_path_change").(bool) {
+		if err := diff.ForceNew("path"); err != nil {
+			return fmt.Errorf("failed to plan replacing ZNode: %w", err)
+		}
+
+		return customizeDiffDestroyImpact("path")(ctx, diff, prvClient)
+	}
+
+	// NOTE: Moving creates different parents, and the subtree may change while moved
+	for _, attr := range []string{"created_parents", "destroy_impact"} {
+		if err := diff.SetNewComputed(attr); err != nil {
+			return fmt.Errorf("failed to plan '%s': %w", attr, err)
+		}
+	}
+
+	return nil
+}
+
 func resourceZNodeCreate(
 	_ context.Context,
 	rscData *schema.ResourceData,
@@ -99,6 +170,10 @@ func resourceZNodeCreate(
 	}
 
 	znode, err := zkClient.Create(znodePath, dataBytes, acls)
+	if errors.Is(err, client.ErrZNodeAlreadyExists) &&
+		rscData.Get("adopt_existing").(string) != adoptExistingFail {
+		znode, err = adoptZNode(zkClient, rscData, dataBytes, acls)
+	}
 	if err != nil {
 		return diag.Errorf("Failed to create ZNode '%s': %v", znodePath, err)
 	}
@@ -107,7 +182,46 @@ func resourceZNodeCreate(
 	rscData.SetId(znode.Path)
 	rscData.MarkNewResource()
 
-	return setAttributesFromZNode(rscData, znode, diag.Diagnostics{})
+	diags := setAttributesFromZNode(rscData, znode, waitForPropagation(zkClient, znode))
+	diags = setCreatedParents(rscData, znode, diags)
+
+	return setDestroyImpact(zkClient, rscData, diags)
+}
+
+// adoptZNode takes ownership of the existing ZNode of the resource, converging its data and ACL
+// according to `adopt_existing`.
+func adoptZNode(
+	zkClient *client.Client,
+	rscData *schema.ResourceData,
+	dataBytes []byte,
+	acls []zk.ACL,
+) (*client.ZNode, error) {
+	znodePath := rscData.Get("path").(string)
+
+	existing, err := zkClient.Read(znodePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adopt existing ZNode: %w", err)
+	}
+
+	// NOTE: When adopting, what is not configured is kept as it is
+	if rscData.Get("adopt_existing").(string) == adoptExistingAdopt {
+		_, dataSet := rscData.GetOk("data")
+		_, dataBase64Set := rscData.GetOk("data_base64")
+		if !dataSet && !dataBase64Set {
+			dataBytes = existing.Data
+		}
+		if _, aclSet := rscData.GetOk("acl"); !aclSet {
+			acls = existing.ACL
+		}
+	}
+
+	fmt.Printf("[DEBUG] Adopting existing ZNode '%s'\n", znodePath)
+	znode, err := zkClient.Update(znodePath, dataBytes, acls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adopt existing ZNode: %w", err)
+	}
+
+	return znode, nil
 }
 
 func resourceZNodeRead(
@@ -115,8 +229,33 @@ func resourceZNodeRead(
 	rscData *schema.ResourceData,
 	prvClient interface{},
 ) diag.Diagnostics {
-	zkClient := prvClient.(*client.Client)
+	diags := readZNode(prvClient.(*client.Client), rscData)
+	if diags.HasError() || rscData.Id() == "" {
+		return diags
+	}
+
+	// NOTE: Unset only when imported: set the defaults, so they are in the state
+	if rscData.Get("adopt_existing").(string) == "" {
+		for attr, value := range map[string]interface{}{
+			"adopt_existing":   adoptExistingFail,
+			"move_descendants": true,
+		} {
+			if err := rscData.Set(attr, value); err != nil {
+				diags = append(diags, diag.FromErr(err)...)
+			}
+		}
+	}
+	if err := rscData.Set("move_on_path_change", rscData.Get("move_on_path_change").(bool)); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
 
+// readZNode reads the ZNode of the resource into the *schema.ResourceData,
+// together with the attributes shared by `zookeeper_znode` and `zookeeper_sequential_znode`
+// (see withDeletePolicySchemas).
+func readZNode(zkClient *client.Client, rscData *schema.ResourceData) diag.Diagnostics {
 	znodePath := rscData.Id()
 
 	znode, err := zkClient.Read(znodePath)
@@ -131,7 +270,17 @@ func resourceZNodeRead(
 		return diag.Errorf("Failed to read ZNode '%s': %v", znodePath, err)
 	}
 
-	return setAttributesFromZNode(rscData, znode, diag.Diagnostics{})
+	diags := setAttributesFromZNode(rscData, znode, diag.Diagnostics{})
+	diags = setDestroyImpact(
+		zkClient,
+		rscData,
+		setDestroyImpactDefaults(
+			rscData,
+			setDeletePolicyDefaults(rscData, deletePolicyRecursive, diags),
+		),
+	)
+
+	return warnDestroyImpact(rscData, diags)
 }
 
 func resourceZNodeUpdate(
@@ -141,28 +290,63 @@ func resourceZNodeUpdate(
 ) diag.Diagnostics {
 	zkClient := prvClient.(*client.Client)
 
-	znodePath := rscData.Id()
+	// NOTE: Read before moving, as moving sets the data and ACL of the moved ZNode
+	dataBytes, err := getDataBytesFromResourceData(rscData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	if rscData.HasChanges("data", "data_base64", "acl") {
-		dataBytes, err := getDataBytesFromResourceData(rscData)
-		if err != nil {
-			return diag.FromErr(err)
-		}
+	acls, err := parseACLsFromResourceData(rscData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-		acls, err := parseACLsFromResourceData(rscData)
-		if err != nil {
-			return diag.FromErr(err)
+	diags := diag.Diagnostics{}
+	if rscData.HasChange("path") {
+		diags = moveZNode(zkClient, rscData)
+		if diags.HasError() {
+			return diags
 		}
+	}
+
+	if rscData.HasChanges("data", "data_base64", "acl") {
+		znodePath := rscData.Id()
 
 		znode, err := zkClient.Update(znodePath, dataBytes, acls)
 		if err != nil {
-			return diag.Errorf("Failed to update ZNode '%s': %v", znodePath, err)
+			return append(diags, diag.Errorf("Failed to update ZNode '%s': %v", znodePath, err)...)
 		}
 
-		return setAttributesFromZNode(rscData, znode, diag.Diagnostics{})
+		diags = setAttributesFromZNode(
+			rscData,
+			znode,
+			append(diags, waitForPropagation(zkClient, znode)...),
+		)
 	}
 
-	return diag.Diagnostics{}
+	return setDestroyImpact(zkClient, rscData, diags)
+}
+
+// moveZNode moves the ZNode of the resource to its new `path` (see `move_on_path_change`).
+func moveZNode(zkClient *client.Client, rscData *schema.ResourceData) diag.Diagnostics {
+	oldPath, newPath := rscData.GetChange("path")
+
+	znode, err := zkClient.MoveSubtree(
+		oldPath.(string),
+		newPath.(string),
+		rscData.Get("move_descendants").(bool),
+	)
+	if err != nil {
+		return diag.Errorf("Failed to move ZNode '%s' to '%s': %v", oldPath, newPath, err)
+	}
+
+	rscData.SetId(znode.Path)
+	managedZNodes.remove(zkClient, oldPath.(string))
+
+	diags := setAttributesFromZNode(rscData, znode, waitForPropagation(zkClient, znode))
+
+	// NOTE: The parents created for the old path are left, as they may be shared with other ZNodes
+	return setCreatedParents(rscData, znode, diags)
 }
 
 func resourceZNodeDelete(
@@ -170,14 +354,5 @@ func resourceZNodeDelete(
 	rscData *schema.ResourceData,
 	prvClient interface{},
 ) diag.Diagnostics {
-	zkClient := prvClient.(*client.Client)
-
-	znodePath := rscData.Id()
-
-	err := zkClient.Delete(znodePath)
-	if err != nil {
-		return diag.Errorf("Failed to delete ZNode '%s': %v", znodePath, err)
-	}
-
-	return diag.Diagnostics{}
+	return deleteZNode(prvClient.(*client.Client), rscData)
 }
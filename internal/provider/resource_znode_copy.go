@@ -0,0 +1,539 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+// Modes of keeping a copy of a subtree in sync with its source.
+const (
+	copyModeOnce      = "once"
+	copyModeReconcile = "reconcile"
+
+	// copyImportIDSeparator separates source and destination paths, in the ID used to import a copy.
+	copyImportIDSeparator = ":/"
+)
+
+// ErrZNodeCopyImport is returned when importing a copy fails.
+var ErrZNodeCopyImport = errors.New("failed to import ZNode copy")
+
+// copyConfigAttrs returns the attributes that define what is copied: changing any of them copies again.
+func copyConfigAttrs() []string {
+	return []string{"source_path", "include", "exclude", "acl", "prune"}
+}
+
+func resourceZNodeCopy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZNodeCopyCreate,
+		ReadContext:   resourceZNodeCopyRead,
+		UpdateContext: resourceZNodeCopyUpdate,
+		DeleteContext: resourceZNodeCopyDelete,
+		CustomizeDiff: resourceZNodeCopyCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceZNodeCopyImport,
+		},
+		Schema: withCopyDeletePolicySchemas(map[string]*schema.Schema{
+			"source_path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Absolute path to the ZNode to copy, with its descendants.",
+			},
+			"destination_path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "Absolute path to copy the ZNode to: the copy is managed by this resource. " +
+					"Can't be an ancestor or a descendant of `source_path`.",
+			},
+			"mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  copyModeOnce,
+				ValidateFunc: validation.StringInSlice(
+					[]string{copyModeOnce, copyModeReconcile},
+					false,
+				),
+				Description: "How the copy is kept in sync with the source: " +
+					"`once` copies when the resource is created (or its configuration changes), " +
+					"and only reports when the copy drifts from the source (see `in_sync`); " +
+					"`reconcile` copies again, on every apply, whenever the copy drifts from the source. " +
+					"Defaults to `once`.",
+			},
+			"include": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateCopyPattern,
+				},
+				Description: "Glob patterns (ex. `config/*`) of the paths, relative to `source_path`, " +
+					"of the descendants to copy, together with their descendants and ancestors. " +
+					"If not set, all descendants are copied.",
+			},
+			"exclude": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateCopyPattern,
+				},
+				Description: "Glob patterns (ex. `*/secrets`) of the paths, relative to `source_path`, " +
+					"of the descendants not to copy, together with their descendants. " +
+					"Takes precedence over `include`.",
+			},
+			"acl": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "List of ACL entries for each ZNode of the copy. " +
+					"If not set, the ACL of each ZNode is copied from the source.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"scheme": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: "The ACL scheme, such as 'world', 'digest', " +
+								"'ip', 'x509'.",
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: "The ID for the ACL entry. For example, " +
+								"user:hash in 'digest' scheme.",
+						},
+						"permissions": {
+							Type:     schema.TypeInt,
+							Required: true,
+							Description: "The permissions for the ACL entry, " +
+								"represented as an integer bitmask.",
+						},
+					},
+				},
+			},
+			"prune": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Delete the descendants of the copy that don't exist in the source " +
+					"(unless filtered out by `include` or `exclude`), and report them as drift. Defaults to `false`.",
+			},
+			"copied_paths": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Absolute paths of the ZNodes of the copy, each before its children.",
+			},
+			"source_digest": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Digest (SHA-256) of the paths, data and ACL copied from the source, " +
+					"when it was last copied.",
+			},
+			"source_changed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the source changed since it was last copied.",
+			},
+			"in_sync": {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: "Whether the copy is in sync with the source, as of the last refresh: " +
+					"it's not if either the source or the copy changed since it was last copied.",
+			},
+			"drifted_paths": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Absolute paths of the ZNodes of the copy that differ from the source, " +
+					"as of the last refresh: missing, with different data or ACL, or extra (if `prune` is set).",
+			},
+		}),
+		Description: "Manages a copy of a " + zNodeLinkForDesc + " and its descendants " +
+			"(ex. to promote configuration from `/staging/app` to `/prod/app`): " +
+			"data and ACL are copied, optionally rewriting the ACL and filtering the paths to copy. " +
+			"Drift of either the source or the copy is reported, and optionally reconciled (see `mode`). " +
+			"Ephemeral ZNodes are copied as persistent ZNodes.",
+	}
+}
+
+// withCopyDeletePolicySchemas adds the attributes of deletePolicySchemas (see withDeletePolicySchemas)
+// to the given resource attributes, with `delete_policy` defaulting to delete only the ZNodes of the copy.
+func withCopyDeletePolicySchemas(schemas map[string]*schema.Schema) map[string]*schema.Schema {
+	schemas = withDeletePolicySchemas(schemas)
+	schemas["delete_policy"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  deletePolicyCopied,
+		ValidateFunc: validation.StringInSlice([]string{
+			deletePolicyCopied,
+			deletePolicyRecursive,
+			deletePolicyFailIfChildren,
+			deletePolicyRetain,
+			deletePolicyTrash,
+		}, false),
+		Description: "What to do with the copy when the resource is destroyed: " +
+			"`copied` deletes only the ZNodes of the copy (i.e. `copied_paths`), deepest first, " +
+			"keeping the ones that have other children (ex. filtered out by `include` or `exclude`, " +
+			"or created by someone else); " +
+			"`recursive` deletes the destination and all its descendants (including the ones not copied); " +
+			"`fail_if_children` deletes the destination only if it has no children, failing otherwise; " +
+			"`retain` leaves the copy in place, and only removes it from the Terraform state; " +
+			"`trash` moves the destination and all its descendants under `trash_path`, " +
+			"so that they can be restored. Defaults to `copied`.",
+	}
+
+	return schemas
+}
+
+// validateCopyPattern validates a glob pattern of `include` or `exclude`.
+func validateCopyPattern(value interface{}, key string) ([]string, []error) {
+	if _, err := path.Match(value.(string), ""); err != nil {
+		return nil, []error{fmt.Errorf("invalid glob pattern '%s' in %s: %w", value, key, err)}
+	}
+
+	return nil, nil
+}
+
+// copyOptionsFromResourceData returns the client.CopyOptions configured in the *schema.ResourceData.
+func copyOptionsFromResourceData(rscData *schema.ResourceData) (*client.CopyOptions, error) {
+	opts := &client.CopyOptions{
+		Prune: rscData.Get("prune").(bool),
+	}
+	for _, pattern := range rscData.Get("include").([]interface{}) {
+		opts.Include = append(opts.Include, pattern.(string))
+	}
+	for _, pattern := range rscData.Get("exclude").([]interface{}) {
+		opts.Exclude = append(opts.Exclude, pattern.(string))
+	}
+
+	// NOTE: Unless rewritten, the ACL is copied from the source
+	if len(rscData.Get("acl").([]interface{})) > 0 {
+		acls, err := parseACLsFromResourceData(rscData)
+		if err != nil {
+			return nil, err
+		}
+		opts.ACL = acls
+	}
+
+	return opts, nil
+}
+
+func resourceZNodeCopyCreate(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	znode, diags := syncCopy(zkClient, rscData)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Terraform will use the destination path as unique identifier for this Resource
+	rscData.SetId(znode.Path)
+	rscData.MarkNewResource()
+
+	diags = setCreatedParents(rscData, znode, diags)
+
+	return setCopyDestroyImpact(zkClient, rscData, diags)
+}
+
+// syncCopy copies the source of the resource to its destination, and populates the *schema.ResourceData
+// with the outcome.
+func syncCopy(
+	zkClient *client.Client,
+	rscData *schema.ResourceData,
+) (*client.ZNode, diag.Diagnostics) {
+	srcPath := rscData.Get("source_path").(string)
+	dstPath := rscData.Get("destination_path").(string)
+
+	opts, err := copyOptionsFromResourceData(rscData)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	znode, copyDiff, err := zkClient.SyncCopy(srcPath, dstPath, opts)
+	if err != nil {
+		return nil, diag.Errorf("Failed to copy ZNode '%s' to '%s': %v", srcPath, dstPath, err)
+	}
+
+	diags := waitForPropagation(zkClient, znode)
+	for attr, value := range map[string]interface{}{
+		"copied_paths":   copyDiff.Paths,
+		"source_digest":  copyDiff.SourceDigest,
+		"source_changed": false,
+		"in_sync":        true,
+		"drifted_paths":  []string{},
+	} {
+		if err := rscData.Set(attr, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return znode, diags
+}
+
+func resourceZNodeCopyRead(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	srcPath := rscData.Get("source_path").(string)
+	dstPath := rscData.Id()
+
+	opts, err := copyOptionsFromResourceData(rscData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// If the copy is not found, it means it was deleted outside of Terraform.
+	// We set the ID to blank, so it's state will be removed.
+	dstExists, err := zkClient.Exists(dstPath)
+	if err != nil {
+		return diag.Errorf("Failed to check existence of copy at '%s': %v", dstPath, err)
+	}
+	if !dstExists {
+		rscData.SetId("")
+		return diag.Diagnostics{}
+	}
+
+	diags := diag.Diagnostics{}
+
+	// NOTE: The copy is kept, even if the source is gone (ex. once promoted, staging was torn down)
+	copyDiff, err := zkClient.DiffCopy(srcPath, dstPath, opts)
+	if errors.Is(err, client.ErrZNodeDoesNotExist) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Source of the copy at '%s' does not exist", dstPath),
+			Detail: fmt.Sprintf(
+				"ZNode '%s' no longer exists: the copy can't be compared with it.",
+				srcPath,
+			),
+		})
+		for attr, value := range map[string]interface{}{"source_changed": true, "in_sync": false} {
+			if err := rscData.Set(attr, value); err != nil {
+				diags = append(diags, diag.FromErr(err)...)
+			}
+		}
+
+		return warnDestroyImpact(
+			rscData,
+			setCopyDestroyImpact(zkClient, rscData, setCopyDefaults(rscData, diags)),
+		)
+	}
+	if err != nil {
+		return diag.Errorf(
+			"Failed to compare ZNode '%s' with its copy at '%s': %v",
+			srcPath,
+			dstPath,
+			err,
+		)
+	}
+
+	// NOTE: When imported, the source is assumed to have been copied as it is
+	if rscData.Get("source_digest").(string) == "" {
+		if err := rscData.Set("source_digest", copyDiff.SourceDigest); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+	sourceChanged := copyDiff.SourceDigest != rscData.Get("source_digest").(string)
+
+	for attr, value := range map[string]interface{}{
+		"copied_paths":   copyDiff.Paths,
+		"source_changed": sourceChanged,
+		"in_sync":        copyDiff.InSync(),
+		"drifted_paths":  copyDiff.Drifted(),
+	} {
+		if err := rscData.Set(attr, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	if !copyDiff.InSync() {
+		detail := fmt.Sprintf(
+			"%d ZNode(s) of the copy differ from the source: %s.",
+			len(copyDiff.Drifted()),
+			strings.Join(copyDiff.Drifted(), ", "),
+		)
+		if sourceChanged {
+			detail += fmt.Sprintf(
+				" ZNode '%s' (the source) changed since it was last copied.",
+				srcPath,
+			)
+		}
+		if rscData.Get("mode").(string) != copyModeReconcile {
+			detail += " Set `mode = \"reconcile\"`, or replace the resource, to copy it again."
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Copy of ZNode '%s' at '%s' has drifted", srcPath, dstPath),
+			Detail:   detail,
+		})
+	}
+
+	return warnDestroyImpact(
+		rscData,
+		setCopyDestroyImpact(zkClient, rscData, setCopyDefaults(rscData, diags)),
+	)
+}
+
+// setCopyDefaults sets the default attributes of a copy, if they are not set (ex. on import).
+func setCopyDefaults(rscData *schema.ResourceData, diags diag.Diagnostics) diag.Diagnostics {
+	if rscData.Get("mode").(string) == "" {
+		if err := rscData.Set("mode", copyModeOnce); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	// NOTE: Explicitly set, so it's in the state even when imported
+	if err := rscData.Set("prune", rscData.Get("prune").(bool)); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return setDestroyImpactDefaults(
+		rscData,
+		setDeletePolicyDefaults(rscData, deletePolicyCopied, diags),
+	)
+}
+
+// setCopyDestroyImpact records the ZNodes of the copy as managed (see managedZNodes),
+// and populates the `destroy_impact` of the copy.
+func setCopyDestroyImpact(
+	zkClient *client.Client,
+	rscData *schema.ResourceData,
+	diags diag.Diagnostics,
+) diag.Diagnostics {
+	for _, copiedPath := range rscData.Get("copied_paths").([]interface{}) {
+		managedZNodes.add(zkClient, copiedPath.(string))
+	}
+
+	return setDestroyImpact(zkClient, rscData, diags)
+}
+
+func resourceZNodeCopyUpdate(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	diags := diag.Diagnostics{}
+	if rscData.HasChanges(copyConfigAttrs()...) ||
+		rscData.Get("mode").(string) == copyModeReconcile {
+		_, diags = syncCopy(zkClient, rscData)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return setCopyDestroyImpact(zkClient, rscData, diags)
+}
+
+func resourceZNodeCopyDelete(
+	_ context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	if rscData.Get("delete_policy").(string) != deletePolicyCopied {
+		return deleteZNode(zkClient, rscData)
+	}
+
+	dstPath := rscData.Id()
+	managedZNodes.remove(zkClient, dstPath)
+
+	// NOTE: Unknown if never compared with the source (ex. imported while the source was missing)
+	copiedPaths := []string{dstPath}
+	if copied := rscData.Get("copied_paths").([]interface{}); len(copied) > 0 {
+		copiedPaths = make([]string, 0, len(copied))
+		for _, copiedPath := range copied {
+			copiedPaths = append(copiedPaths, copiedPath.(string))
+		}
+	}
+
+	kept, err := zkClient.DeleteCopy(copiedPaths)
+	if err != nil {
+		return diag.Errorf("Failed to delete copy at '%s': %v", dstPath, err)
+	}
+	if len(kept) > 0 {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Copy at '%s' partially retained", dstPath),
+				Detail: fmt.Sprintf(
+					"The following ZNodes of the copy have children that are not part of it, "+
+						"so they were kept: %s.",
+					strings.Join(kept, ", "),
+				),
+			},
+		}
+	}
+
+	return deleteCreatedParents(zkClient, rscData)
+}
+
+func resourceZNodeCopyImport(
+	ctx context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) ([]*schema.ResourceData, error) {
+	srcPath, dstPath, found := strings.Cut(rscData.Id(), copyImportIDSeparator)
+	if !found {
+		return nil, fmt.Errorf(
+			"%w: expected ID in the format '<source_path>:<destination_path>', got '%s'",
+			ErrZNodeCopyImport,
+			rscData.Id(),
+		)
+	}
+	dstPath = "/" + dstPath
+
+	rscData.SetId(dstPath)
+	for attr, value := range map[string]interface{}{"source_path": srcPath, "destination_path": dstPath} {
+		if err := rscData.Set(attr, value); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrZNodeCopyImport, err)
+		}
+	}
+
+	if diags := resourceZNodeCopyRead(ctx, rscData, prvClient); diags.HasError() {
+		return nil, fmt.Errorf("%w: %s", ErrZNodeCopyImport, diags[0].Summary)
+	}
+
+	return []*schema.ResourceData{rscData}, nil
+}
+
+// resourceZNodeCopyCustomizeDiff plans to copy again, when the copy has drifted and is reconciled
+// (see `mode`), or when what is copied changes.
+func resourceZNodeCopyCustomizeDiff(
+	ctx context.Context,
+	diff *schema.ResourceDiff,
+	prvClient interface{},
+) error {
+	if diff.Id() != "" && (diff.HasChanges(copyConfigAttrs()...) ||
+		(diff.Get("mode").(string) == copyModeReconcile && !diff.Get("in_sync").(bool))) {
+		for _, attr := range []string{
+			"copied_paths",
+			"source_digest",
+			"source_changed",
+			"in_sync",
+			"drifted_paths",
+		} {
+			if err := diff.SetNewComputed(attr); err != nil {
+				return fmt.Errorf("failed to plan '%s': %w", attr, err)
+			}
+		}
+	}
+
+	return customizeDiffDestroyImpact("destination_path")(ctx, diff, prvClient)
+}
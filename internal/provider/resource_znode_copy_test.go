@@ -0,0 +1,272 @@
+package provider_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-zookeeper/zk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func TestAccResourceZNodeCopy(t *testing.T) {
+	rootPath := "/" + acctest.RandString(10)
+
+	config := func(mode string) string {
+		return fmt.Sprintf(`
+			resource "zookeeper_znode" "url" {
+				path = "%[1]s/staging/app/db/url"
+				data = "jdbc:staging"
+			}
+			resource "zookeeper_znode" "key" {
+				path = "%[1]s/staging/app/secrets/key"
+				data = "secret"
+			}
+			resource "zookeeper_znode_copy" "app" {
+				source_path      = "%[1]s/staging/app"
+				destination_path = "%[1]s/prod/app"
+				exclude          = ["secrets"]
+				mode             = "%[2]s"
+
+				depends_on = [zookeeper_znode.url, zookeeper_znode.key]
+			}`, rootPath, mode,
+		)
+	}
+
+	// tamperWithCopy changes the data of a ZNode of the copy "by hand"
+	tamperWithCopy := func() {
+		zkClient, err := client.NewClientFromEnv()
+		if err != nil {
+			t.Fatalf("failed to create new Client: %v", err)
+		}
+		defer zkClient.Close()
+
+		_, err = zkClient.Update(
+			rootPath+"/prod/app/db/url",
+			[]byte("tampered"),
+			zk.WorldACL(zk.PermAll),
+		)
+		if err != nil {
+			t.Fatalf("failed to update ZNode: %v", err)
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:      confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: config("reconcile"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"id",
+						rootPath+"/prod/app",
+					),
+					resource.TestCheckResourceAttr("zookeeper_znode_copy.app", "in_sync", "true"),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"source_changed",
+						"false",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"copied_paths.#",
+						"3",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"copied_paths.2",
+						rootPath+"/prod/app/db/url",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"drifted_paths.#",
+						"0",
+					),
+					resource.TestCheckResourceAttrSet("zookeeper_znode_copy.app", "source_digest"),
+				),
+			},
+			{
+				// Reconciled: the copy is copied again
+				PreConfig: tamperWithCopy,
+				Config:    config("reconcile"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode_copy.app", "in_sync", "true"),
+					func(_ *terraform.State) error {
+						zkClient, err := client.NewClientFromEnv()
+						if err != nil {
+							return fmt.Errorf("failed to create new Client: %w", err)
+						}
+						defer zkClient.Close()
+
+						url, err := zkClient.Read(rootPath + "/prod/app/db/url")
+						if err != nil {
+							return fmt.Errorf("failed to read copy: %w", err)
+						}
+						if string(url.Data) != "jdbc:staging" {
+							return fmt.Errorf("copy not reconciled: %q", url.Data) //nolint:err113
+						}
+
+						if exists, _ := zkClient.Exists(rootPath + "/prod/app/secrets"); exists {
+							return errors.New("excluded ZNode was copied") //nolint:err113
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				// Once: the drift is only reported
+				PreConfig: tamperWithCopy,
+				Config:    config("once"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode_copy.app", "in_sync", "false"),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"source_changed",
+						"false",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"drifted_paths.#",
+						"1",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"drifted_paths.0",
+						rootPath+"/prod/app/db/url",
+					),
+				),
+			},
+			{
+				// Deleted outside of Terraform: the copy is copied again
+				PreConfig: func() {
+					zkClient, err := client.NewClientFromEnv()
+					if err != nil {
+						t.Fatalf("failed to create new Client: %v", err)
+					}
+					defer zkClient.Close()
+
+					if err := zkClient.Delete(rootPath + "/prod/app"); err != nil {
+						t.Fatalf("failed to delete copy: %v", err)
+					}
+				},
+				Config: config("once"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode_copy.app", "in_sync", "true"),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"copied_paths.#",
+						"3",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"drifted_paths.#",
+						"0",
+					),
+				),
+			},
+			{
+				ResourceName:      "zookeeper_znode_copy.app",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%[1]s/staging/app:%[1]s/prod/app", rootPath),
+				ImportStateVerify: true,
+				// NOTE: Filters can't be imported, so the copy is compared with the whole source
+				ImportStateVerifyIgnore: []string{
+					"created_parents",
+					"exclude",
+					"copied_paths",
+					"drifted_paths",
+					"source_digest",
+					"destroy_impact",
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceZNodeCopy_DeletePolicy(t *testing.T) {
+	rootPath := "/" + acctest.RandString(10)
+
+	config := fmt.Sprintf(`
+		resource "zookeeper_znode" "url" {
+			path = "%[1]s/staging/app/db/url"
+			data = "jdbc:staging"
+		}
+		resource "zookeeper_znode_copy" "app" {
+			source_path      = "%[1]s/staging/app"
+			destination_path = "%[1]s/prod/app"
+
+			depends_on = [zookeeper_znode.url]
+		}`, rootPath,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		// Only the ZNodes of the copy are deleted: the ZNode created "by hand" under it is kept
+		CheckDestroy: func(_ *terraform.State) error {
+			zkClient, err := client.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create new Client: %w", err)
+			}
+			defer zkClient.Close()
+			defer zkClient.Delete(rootPath) //nolint:errcheck
+
+			if exists, _ := zkClient.Exists(rootPath + "/prod/app/db"); exists {
+				return errors.New("copied ZNode was not deleted") //nolint:err113
+			}
+			if exists, _ := zkClient.Exists(rootPath + "/prod/app/runtime"); !exists {
+				return errors.New("ZNode created outside of the copy was deleted") //nolint:err113
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"delete_policy",
+						"copied",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_copy.app",
+						"copied_paths.#",
+						"3",
+					),
+				),
+			},
+			{
+				PreConfig: func() {
+					zkClient, err := client.NewClientFromEnv()
+					if err != nil {
+						t.Fatalf("failed to create new Client: %v", err)
+					}
+					defer zkClient.Close()
+
+					_, err = zkClient.Create(
+						rootPath+"/prod/app/runtime",
+						[]byte("runtime"),
+						zk.WorldACL(zk.PermAll),
+					)
+					if err != nil {
+						t.Fatalf("failed to create ZNode: %v", err)
+					}
+				},
+				Config: config,
+				Check: resource.TestCheckResourceAttr(
+					"zookeeper_znode_copy.app",
+					"destroy_impact.0.unmanaged_descendant_count",
+					"1",
+				),
+			},
+		},
+	})
+}
@@ -2,10 +2,18 @@ package provider_test
 
 import (
 	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-zookeeper/zk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
 )
 
 func TestAccResourceZNode(t *testing.T) {
@@ -66,11 +74,14 @@ func TestAccResourceZNode(t *testing.T) {
 				ResourceName:      "zookeeper_znode.parent",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// NOTE: When imported alone, `child` is not known to be managed
+				ImportStateVerifyIgnore: []string{"created_parents", "destroy_impact"},
 			},
 			{
-				ResourceName:      "zookeeper_znode.child",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "zookeeper_znode.child",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_parents"},
 			},
 		},
 	})
@@ -162,14 +173,16 @@ func TestAccResourceZNode_Base64(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      "zookeeper_znode.src",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "zookeeper_znode.src",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_parents"},
 			},
 			{
-				ResourceName:      "zookeeper_znode.dst",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "zookeeper_znode.dst",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_parents"},
 			},
 		},
 	})
@@ -269,3 +282,490 @@ func TestAccResourceZNode_WithACL(t *testing.T) {
 		},
 	})
 }
+
+func TestAccResourceZNode_DeletePolicy(t *testing.T) {
+	parentPath := "/" + acctest.RandString(10)
+	trashPath := parentPath + "/trash"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		CheckDestroy: func(_ *terraform.State) error {
+			zkClient, err := client.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create new Client: %w", err)
+			}
+			defer zkClient.Close()
+
+			// The retained ZNode is still there, with the trashed one moved under the trash
+			stats, err := zkClient.SubtreeStats(parentPath)
+			if err != nil {
+				return fmt.Errorf("retained ZNode '%s' not found: %w", parentPath, err)
+			}
+			if exists, _ := zkClient.Exists(parentPath + "/trashed"); exists {
+				return fmt.Errorf("ZNode '%s/trashed' still exists", parentPath) //nolint:err113
+			}
+			// i.e. `trash`, `<timestamp>`, and the moved `/<parent>/trashed` (`child` is deleted before it)
+			if stats.DescendantCount != 4 {
+				return fmt.Errorf( //nolint:err113
+					"expected 4 descendants of '%s', found %d",
+					parentPath,
+					stats.DescendantCount,
+				)
+			}
+
+			// The trash was created with `trash_acl` (i.e. read, create and delete)
+			trash, err := zkClient.Read(trashPath)
+			if err != nil {
+				return fmt.Errorf("trash '%s' not found: %w", trashPath, err)
+			}
+			if !reflect.DeepEqual(trash.ACL, zk.WorldACL(zk.PermRead|zk.PermCreate|zk.PermDelete)) {
+				return fmt.Errorf(
+					"unexpected ACL of trash '%s': %v",
+					trashPath,
+					trash.ACL,
+				) //nolint:err113
+			}
+
+			return zkClient.Delete(parentPath)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "retained" {
+						path          = "%s"
+						delete_policy = "retain"
+					}
+					resource "zookeeper_znode" "trashed" {
+						path          = "${zookeeper_znode.retained.path}/trashed"
+						data          = "trashed data"
+						delete_policy = "trash"
+						trash_path    = "%s"
+						trash_acl {
+							scheme      = "world"
+							id          = "anyone"
+							permissions = 13
+						}
+					}
+					resource "zookeeper_znode" "child" {
+						path          = "${zookeeper_znode.trashed.path}/child"
+						delete_policy = "fail_if_children"
+					}`, parentPath, trashPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.retained",
+						"delete_policy",
+						"retain",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.trashed",
+						"trash_path",
+						trashPath,
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.child",
+						"delete_policy",
+						"fail_if_children",
+					),
+				),
+			},
+			{
+				ResourceName:            "zookeeper_znode.child",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"delete_policy", "created_parents"},
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_DeleteEphemerals(t *testing.T) {
+	path := "/" + acctest.RandString(10)
+	config := func(deleteEphemerals bool) string {
+		return fmt.Sprintf(`
+			resource "zookeeper_znode" "services" {
+				path              = "%s"
+				delete_ephemerals = %t
+			}`, path, deleteEphemerals,
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:      confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+			},
+			{
+				// Register an "instance" under the ZNode, via a live session
+				PreConfig: func() {
+					conn, _, err := zk.Connect(
+						strings.Split(os.Getenv(client.EnvZooKeeperServer), ","),
+						10*time.Second,
+						zk.WithLogInfo(false),
+					)
+					if err != nil {
+						t.Fatalf("failed to connect: %v", err)
+					}
+					t.Cleanup(conn.Close)
+
+					_, err = conn.Create(
+						path+"/instance-1",
+						nil,
+						zk.FlagEphemeral,
+						zk.WorldACL(zk.PermAll),
+					)
+					if err != nil {
+						t.Fatalf("failed to create ephemeral ZNode: %v", err)
+					}
+				},
+				Config:      config(false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("its subtree contains ephemeral ZNodes"),
+			},
+			{
+				Config: config(true),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_DestroyImpact(t *testing.T) {
+	parentPath := "/" + acctest.RandString(10)
+	config := fmt.Sprintf(`
+		resource "zookeeper_znode" "parent" {
+			path = "%s"
+			data = "parent"
+		}`, parentPath,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:      confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.parent",
+						"track_destroy_impact",
+						"true",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.parent",
+						"destroy_impact.0.unmanaged_descendant_count",
+						"0",
+					),
+				),
+			},
+			{
+				// A ZNode is created "by hand" (ex. by an application at runtime)
+				PreConfig: func() {
+					zkClient, err := client.NewClientFromEnv()
+					if err != nil {
+						t.Fatalf("failed to create new Client: %v", err)
+					}
+					defer zkClient.Close()
+
+					_, err = zkClient.Create(
+						parentPath+"/runtime",
+						[]byte("runtime"),
+						zk.WorldACL(zk.PermAll),
+					)
+					if err != nil {
+						t.Fatalf("failed to create ZNode: %v", err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.parent",
+						"destroy_impact.0.unmanaged_descendant_count",
+						"1",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.parent",
+						"destroy_impact.0.unmanaged_data_bytes",
+						"7",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.parent",
+						"destroy_impact.0.total_descendant_count",
+						"1",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.parent",
+						"destroy_impact.0.data_bytes",
+						"13",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.parent",
+						"destroy_impact.0.ephemeral_count",
+						"0",
+					),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "parent" {
+						path                 = "%s"
+						data                 = "parent"
+						track_destroy_impact = false
+					}`, parentPath,
+				),
+				Check: resource.TestCheckResourceAttr(
+					"zookeeper_znode.parent",
+					"destroy_impact.#",
+					"0",
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_DeleteCreatedParents(t *testing.T) {
+	rootPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		CheckDestroy: func(_ *terraform.State) error {
+			zkClient, err := client.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create new Client: %w", err)
+			}
+			defer zkClient.Close()
+
+			if exists, _ := zkClient.Exists(rootPath); exists {
+				return fmt.Errorf("created parent '%s' still exists", rootPath) //nolint:err113
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "deep" {
+						path                   = "%s/parent/deep"
+						delete_created_parents = true
+					}`, rootPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.deep",
+						"created_parents.#",
+						"2",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.deep",
+						"created_parents.0",
+						rootPath,
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.deep",
+						"created_parents.1",
+						rootPath+"/parent",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_AdoptExisting(t *testing.T) {
+	parentPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		CheckDestroy: func(s *terraform.State) error {
+			if err := confirmAllZNodeDestroyed(s); err != nil {
+				return err
+			}
+
+			zkClient, err := client.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create new Client: %w", err)
+			}
+			defer zkClient.Close()
+
+			// NOTE: The parent was created implicitly, along with the ZNodes made "by hand", so it's deleted "by hand"
+			if err := zkClient.Delete(parentPath); err != nil {
+				return fmt.Errorf("failed to delete ZNode '%s': %w", parentPath, err)
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create the ZNodes "by hand"
+				PreConfig: func() {
+					zkClient, err := client.NewClientFromEnv()
+					if err != nil {
+						t.Fatalf("failed to create new Client: %v", err)
+					}
+					defer zkClient.Close()
+
+					for _, name := range []string{"failed", "adopted", "overwritten"} {
+						_, err := zkClient.Create(
+							parentPath+"/"+name,
+							[]byte("hand-made"),
+							zk.WorldACL(zk.PermRead|zk.PermWrite|zk.PermAdmin|zk.PermDelete),
+						)
+						if err != nil {
+							t.Fatalf("failed to create ZNode: %v", err)
+						}
+					}
+				},
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "failed" {
+						path = "%s/failed"
+					}`, parentPath,
+				),
+				ExpectError: regexp.MustCompile("node already exists"),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "failed" {
+						path           = "%[1]s/failed"
+						adopt_existing = "overwrite"
+					}
+					resource "zookeeper_znode" "adopted" {
+						path           = "%[1]s/adopted"
+						adopt_existing = "adopt"
+					}
+					resource "zookeeper_znode" "overwritten" {
+						path           = "%[1]s/overwritten"
+						data           = "terraform-made"
+						adopt_existing = "overwrite"
+					}`, parentPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.failed", "data", ""),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.failed",
+						"acl.0.permissions",
+						"31",
+					),
+					resource.TestCheckResourceAttr("zookeeper_znode.adopted", "data", "hand-made"),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.adopted",
+						"acl.0.permissions",
+						"29",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.overwritten",
+						"data",
+						"terraform-made",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.overwritten",
+						"acl.0.permissions",
+						"31",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_MoveOnPathChange(t *testing.T) {
+	parentPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:      confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "moved" {
+						path                = "%s/before"
+						data                = "moving"
+						move_on_path_change = true
+					}`, parentPath,
+				),
+			},
+			{
+				// Create a child "by hand", that replacing the ZNode would delete
+				PreConfig: func() {
+					zkClient, err := client.NewClientFromEnv()
+					if err != nil {
+						t.Fatalf("failed to create new Client: %v", err)
+					}
+					defer zkClient.Close()
+
+					_, err = zkClient.Create(
+						parentPath+"/before/unmanaged",
+						[]byte("hand-made"),
+						zk.WorldACL(zk.PermAll),
+					)
+					if err != nil {
+						t.Fatalf("failed to create ZNode: %v", err)
+					}
+				},
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "moved" {
+						path                = "%s/after"
+						data                = "moved"
+						move_on_path_change = true
+					}`, parentPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.moved",
+						"id",
+						parentPath+"/after",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.moved",
+						"path",
+						parentPath+"/after",
+					),
+					resource.TestCheckResourceAttr("zookeeper_znode.moved", "data", "moved"),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode.moved",
+						"stat.0.num_children",
+						"1",
+					),
+					func(_ *terraform.State) error {
+						zkClient, err := client.NewClientFromEnv()
+						if err != nil {
+							return fmt.Errorf("failed to create new Client: %w", err)
+						}
+						defer zkClient.Close()
+
+						child, err := zkClient.Read(parentPath + "/after/unmanaged")
+						if err != nil {
+							return fmt.Errorf("unmanaged child was not moved: %w", err)
+						}
+						if string(child.Data) != "hand-made" {
+							return fmt.Errorf(
+								"unexpected data of moved child: %q",
+								child.Data,
+							) //nolint:err113
+						}
+						if exists, _ := zkClient.Exists(parentPath + "/before"); exists {
+							return fmt.Errorf(
+								"ZNode '%s/before' still exists",
+								parentPath,
+							) //nolint:err113
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "zookeeper_znode.moved",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_parents"},
+			},
+		},
+	})
+}
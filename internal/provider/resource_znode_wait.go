@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+const defaultWaitTimeoutSec = 300
+
+func resourceZNodeWait() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZNodeWaitCreate,
+		ReadContext:   resourceZNodeWaitRead,
+		DeleteContext: resourceZNodeWaitDelete,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Absolute path to the ZNode to wait for.",
+			},
+			"data_equals": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"data_matches"},
+				Description: "Wait until the data of the ZNode, as a UTF-8 string, is equal to this " +
+					"(ex. `\"\"` for empty data). Mutually exclusive with `data_matches`.",
+			},
+			"data_matches": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"data_equals"},
+				ValidateFunc:  validation.StringIsValidRegExp,
+				Description: "Wait until the data of the ZNode, as a UTF-8 string, matches this regular expression " +
+					"(ex. `^ready`). Mutually exclusive with `data_equals`.",
+			},
+			"min_children": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Wait until the ZNode has at least this many children (ex. registered workers).",
+			},
+			"timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      defaultWaitTimeoutSec,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "How many seconds to wait for the condition to hold, before failing. " +
+					"Defaults to `300`.",
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that, when changed, replace the resource and wait again " +
+					"(ex. the version of the application being deployed).",
+			},
+			"data": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Content of the ZNode, as a UTF-8 string, when the condition held.",
+			},
+			"data_base64": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Content of the ZNode, as Base64 encoded bytes, when the condition held.",
+			},
+			"stat": statSchema(),
+		},
+		Description: "Waits, when created, until a " + zNodeLinkForDesc + " satisfies a condition " +
+			"(ex. an application wrote its readiness ZNode, or enough workers registered under a path): " +
+			"it exists and, if set, its data is equal to `data_equals` (or matches `data_matches`) " +
+			"and it has at least `min_children` children. " +
+			"Rather than polling, it watches the ZNode, and checks again whenever it changes. " +
+			"Once the condition held, it doesn't wait again unless replaced (see `triggers`), " +
+			"and destroying it has no effect on the ZNode.",
+	}
+}
+
+func resourceZNodeWaitCreate(
+	ctx context.Context,
+	rscData *schema.ResourceData,
+	prvClient interface{},
+) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	znodePath := rscData.Get("path").(string)
+
+	cond := &client.WaitCondition{
+		MinChildren: rscData.Get("min_children").(int),
+	}
+	// NOTE: Waiting for empty data is possible, so `data_equals` is set even if empty
+	if !rscData.GetRawConfig().GetAttr("data_equals").IsNull() {
+		cond.DataEquals = []byte(rscData.Get("data_equals").(string))
+	}
+	if dataMatches, ok := rscData.GetOk("data_matches"); ok {
+		cond.DataMatches = regexp.MustCompile(dataMatches.(string))
+	}
+
+	timeout := time.Duration(rscData.Get("timeout").(int)) * time.Second
+	znode, err := zkClient.WaitFor(ctx, znodePath, cond, timeout)
+	if err != nil {
+		return diag.Errorf("Failed waiting for ZNode '%s': %v", znodePath, err)
+	}
+
+	rscData.SetId(znode.Path)
+
+	diags := diag.Diagnostics{}
+	for attr, value := range map[string]interface{}{
+		"data":        string(znode.Data),
+		"data_base64": base64.StdEncoding.EncodeToString(znode.Data),
+		"stat":        []interface{}{zNodeStatToMap(znode)},
+	} {
+		if err := rscData.Set(attr, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
+
+func resourceZNodeWaitRead(
+	_ context.Context,
+	_ *schema.ResourceData,
+	_ interface{},
+) diag.Diagnostics {
+	// NOTE: The ZNode is not read again, as the state records when the condition held
+	return diag.Diagnostics{}
+}
+
+func resourceZNodeWaitDelete(
+	_ context.Context,
+	_ *schema.ResourceData,
+	_ interface{},
+) diag.Diagnostics {
+	// NOTE: Nothing to delete, the resource is just removed from the state
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,115 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+)
+
+func TestAccResourceZNodeWait(t *testing.T) {
+	rootPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { checkPreconditions(t) },
+		ProviderFactories: providerFactoriesMap(),
+		CheckDestroy: func(_ *terraform.State) error {
+			zkClient, err := client.NewClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to create new Client: %w", err)
+			}
+			defer zkClient.Close()
+
+			// NOTE: Waiting has no effect on the ZNode, so it's deleted "by hand"
+			if err := zkClient.Delete(rootPath); err != nil {
+				return fmt.Errorf("failed to delete ZNode '%s': %w", rootPath, err)
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// The application becomes ready "by hand", while waiting for it
+				PreConfig: func() {
+					go func() {
+						zkClient, err := client.NewClientFromEnv()
+						if err != nil {
+							return
+						}
+						defer zkClient.Close()
+
+						time.Sleep(time.Second)
+						_, _ = zkClient.Create(
+							rootPath+"/app/ready",
+							[]byte("starting"),
+							zk.WorldACL(zk.PermAll),
+						)
+						time.Sleep(time.Second)
+						_, _ = zkClient.Update(
+							rootPath+"/app/ready",
+							[]byte("ready: v1"),
+							zk.WorldACL(zk.PermAll),
+						)
+					}()
+				},
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode_wait" "ready" {
+						path         = "%s/app/ready"
+						data_matches = "^ready: "
+						timeout      = 30
+					}`, rootPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_wait.ready",
+						"id",
+						rootPath+"/app/ready",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_wait.ready",
+						"data",
+						"ready: v1",
+					),
+					resource.TestCheckResourceAttr(
+						"zookeeper_znode_wait.ready",
+						"stat.0.version",
+						"1",
+					),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode_wait" "ready" {
+						path         = "%s/app/ready"
+						data_matches = "^ready: "
+						timeout      = 30
+					}
+					resource "zookeeper_znode_wait" "workers" {
+						path         = "%s/app"
+						min_children = 2
+						timeout      = 1
+					}`, rootPath, rootPath,
+				),
+				// NOTE: Terraform wraps long error messages
+				ExpectError: regexp.MustCompile(`1\s+children,\s+instead\s+of\s+at\s+least\s+2`),
+			},
+			{
+				// Empty data is waited for too
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode_wait" "empty" {
+						path        = "%s/app/ready"
+						data_equals = ""
+						timeout     = 1
+					}`, rootPath,
+				),
+				ExpectError: regexp.MustCompile(`its\s+data\s+is\s+not\s+the\s+expected\s+one`),
+			},
+		},
+	})
+}